@@ -3,12 +3,14 @@ package main
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 
 	"github.com/nicholaskarlson/proof-first-finance-calc/internal/api"
 	"github.com/nicholaskarlson/proof-first-finance-calc/internal/calc"
@@ -31,8 +33,12 @@ func main() {
 	case "version", "--version", "-v":
 		fmt.Printf("fincalc %s\n", version)
 		return
+	case "calc":
+		err = cmdCalc(args)
 	case "demo":
 		err = cmdDemo(args)
+	case "payoff":
+		err = cmdPayoff(args)
 	case "serve":
 		err = cmdServe(args)
 	case "help", "-h", "--help":
@@ -55,16 +61,146 @@ func usage() {
 
 Usage:
   fincalc version
+  fincalc calc  <calculator> [--in <request.json>] [--csv] [--holidays <file>]
   fincalc demo  --out <dir> [--fixtures fixtures]
-  fincalc serve --addr <host:port>
+  fincalc payoff [--in <request.json>] [--date YYYY-MM-DD] [--payments-made N] [--holidays <file>]
+  fincalc serve --addr <host:port> [--holidays <file>]
 
 Commands:
   version Print version and exit.
+  calc   Run one calculator on a JSON request (file or stdin) and print the
+         response JSON, or the schedule CSV with --csv.
   demo   Recompute known cases from fixtures and verify outputs match goldens.
+  payoff Quote the payoff of a loan (a payoff request, as for POST /v1/payoff);
+         --date and --payments-made override payoff_date and payments_made.
   serve  Run the HTTP API server (v1).
 `)
 }
 
+func cmdCalc(args []string) error {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return fmt.Errorf("calc: calculator name is required (%s)", suiteNames())
+	}
+	name := args[0]
+	fs := flag.NewFlagSet("calc", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	in := fs.String("in", "", "Request JSON file (default stdin)")
+	asCSV := fs.Bool("csv", false, "Print the schedule CSV instead of the response JSON")
+	holidays := fs.String("holidays", "", "Holiday calendar file (one YYYY-MM-DD per line)")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	var s *suite
+	for i := range suites {
+		if suites[i].name == name {
+			s = &suites[i]
+		}
+	}
+	if s == nil {
+		return fmt.Errorf("calc: unknown calculator %q (%s)", name, suiteNames())
+	}
+
+	body, err := readRequest(*in)
+	if err != nil {
+		return err
+	}
+	cal, err := optionalHolidayCalendar(*holidays)
+	if err != nil {
+		return err
+	}
+
+	files, err := s.compute(cal, body)
+	if err != nil {
+		return err
+	}
+	out := files[0]
+	if *asCSV {
+		if len(files) < 2 {
+			return fmt.Errorf("calc: %s has no schedule", name)
+		}
+		out = files[1]
+	}
+	_, err = os.Stdout.Write(out.data)
+	return err
+}
+
+// cmdPayoff quotes a payoff, optionally overriding the request's
+// payoff_date and payments_made, and prints the response JSON.
+func cmdPayoff(args []string) error {
+	fs := flag.NewFlagSet("payoff", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	in := fs.String("in", "", "Payoff request JSON file (default stdin)")
+	date := fs.String("date", "", "Payoff date YYYY-MM-DD (overrides payoff_date)")
+	paymentsMade := fs.Int("payments-made", -1, "Installments paid (overrides payments_made)")
+	holidays := fs.String("holidays", "", "Holiday calendar file (one YYYY-MM-DD per line)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	body, err := readRequest(*in)
+	if err != nil {
+		return err
+	}
+	req, err := decodeStrict[calc.PayoffRequestV1](body)
+	if err != nil {
+		return err
+	}
+	if *date != "" {
+		req.PayoffDate = *date
+	}
+	if *paymentsMade >= 0 {
+		req.PaymentsMade = *paymentsMade
+	}
+	cal, err := optionalHolidayCalendar(*holidays)
+	if err != nil {
+		return err
+	}
+
+	resp, _, err := calc.PayoffV1WithCalendar(req, cal)
+	if err != nil {
+		return err
+	}
+	out, err := calc.RenderPayoffResponseJSON(resp)
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(out)
+	return err
+}
+
+// readRequest reads a request body from path, or stdin when path is empty.
+func readRequest(path string) ([]byte, error) {
+	var body []byte
+	var err error
+	if path == "" {
+		body, err = io.ReadAll(os.Stdin)
+	} else {
+		body, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read request: %w", err)
+	}
+	return body, nil
+}
+
+// optionalHolidayCalendar loads the --holidays file, or returns nil when
+// none was given.
+func optionalHolidayCalendar(path string) (*calc.HolidayCalendar, error) {
+	if path == "" {
+		return nil, nil
+	}
+	return loadHolidayCalendar(path)
+}
+
+func suiteNames() string {
+	names := make([]string, len(suites))
+	for i, s := range suites {
+		names[i] = s.name
+	}
+	return strings.Join(names, ", ")
+}
+
 func cmdDemo(args []string) error {
 	fs := flag.NewFlagSet("demo", flag.ContinueOnError)
 	fs.SetOutput(os.Stderr)
@@ -77,10 +213,160 @@ func cmdDemo(args []string) error {
 		return fmt.Errorf("--out is required")
 	}
 
-	inRoot := filepath.Join(*fixtures, "input")
+	total := 0
+	for _, s := range suites {
+		n, err := runSuite(s, *fixtures, *outDir)
+		if err != nil {
+			return err
+		}
+		total += n
+	}
+
+	fmt.Fprintf(os.Stdout, "OK: demo outputs match fixtures (%d case(s))\n", total)
+	return nil
+}
+
+// outFile is one rendered output of a fixture case.
+type outFile struct {
+	name string
+	data []byte
+}
+
+// suite is one calculator's fixture set. Cases live under
+// <fixtures>/<dir>/input and <fixtures>/<dir>/expected and are written to
+// <out>/<dir>. compute decodes a request and returns the rendered outputs
+// (response.json first), or the calculator error to compare against
+// error.txt.
+type suite struct {
+	name    string
+	dir     string
+	compute func(cal *calc.HolidayCalendar, body []byte) ([]outFile, error)
+}
+
+// suites lists every calculator the demo verifies and `fincalc calc` runs.
+// Amortization predates the per-calculator layout and keeps its fixtures at
+// the fixtures root.
+var suites = []suite{
+	scheduleSuite("amortize", "", calc.AmortizeV1WithCalendar, calc.RenderResponseJSON, calc.RenderScheduleCSV),
+	scheduleSuite("annuity", "annuity", noCalendar(calc.AnnuityV1), calc.RenderAnnuityResponseJSON, calc.RenderSavingsScheduleCSV),
+	scheduleSuite("apr", "apr", calc.AprV1WithCalendar, calc.RenderAprResponseJSON, calc.RenderScheduleCSV),
+	scheduleSuite("arm", "arm", calc.ArmV1WithCalendar, calc.RenderArmResponseJSON, calc.RenderArmScheduleCSV),
+	scheduleSuite("bond_price", "bond_price", noCalendar(calc.BondPriceV1), calc.RenderBondResponseJSON, calc.RenderBondCashFlowsCSV),
+	scheduleSuite("bond_yield", "bond_yield", noCalendar(calc.BondYieldV1), calc.RenderBondResponseJSON, calc.RenderBondCashFlowsCSV),
+	scheduleSuite("credit_card", "credit_card", noCalendar(calc.CreditCardV1), calc.RenderCreditCardResponseJSON, calc.RenderCreditCardScheduleCSV),
+	scheduleSuite("deferment", "deferment", noCalendar(calc.DefermentV1), calc.RenderDefermentResponseJSON, calc.RenderDefermentScheduleCSV),
+	scheduleSuite("depreciation", "depreciation", noCalendar(calc.DepreciationV1), calc.RenderDepreciationResponseJSON, calc.RenderDepreciationScheduleCSV),
+	scheduleSuite("future_value", "future_value", noCalendar(calc.FutureValueV1), calc.RenderFutureValueResponseJSON, calc.RenderSavingsScheduleCSV),
+	scheduleSuite("graduated", "graduated", noCalendar(calc.GraduatedV1), calc.RenderGraduatedResponseJSON, calc.RenderGraduatedScheduleCSV),
+	summarySuite("irr", "irr", calc.IrrV1, calc.RenderIrrResponseJSON),
+	scheduleSuite("negam", "negam", noCalendar(calc.NegAmV1), calc.RenderNegAmResponseJSON, calc.RenderNegAmScheduleCSV),
+	summarySuite("npv", "npv", calc.NpvV1, calc.RenderNpvResponseJSON),
+	scheduleSuite("payoff", "payoff", calc.PayoffV1WithCalendar, calc.RenderPayoffResponseJSON, calc.RenderScheduleCSV),
+	scheduleSuite("piti", "piti", calc.PitiV1WithCalendar, calc.RenderPitiResponseJSON, calc.RenderPitiScheduleCSV),
+	scheduleSuite("precomputed", "precomputed", noCalendar(calc.PrecomputedV1), calc.RenderPrecomputedResponseJSON, calc.RenderPrecomputedScheduleCSV),
+	scheduleSuite("refinance", "refinance", calc.RefinanceV1WithCalendar, calc.RenderRefinanceResponseJSON, calc.RenderRefinanceScheduleCSV),
+	scheduleSuite("simple_interest", "simple_interest", noCalendar(calc.SimpleInterestV1), calc.RenderSimpleInterestResponseJSON, calc.RenderSimpleInterestLedgerCSV),
+	scheduleSuite("sinking_fund", "sinking_fund", noCalendar(calc.SinkingFundV1), calc.RenderSinkingFundResponseJSON, calc.RenderSavingsScheduleCSV),
+	scheduleSuite("solve_principal", "solve_principal", noCalendar(calc.SolvePrincipalV1), calc.RenderSolveResponseJSON, calc.RenderScheduleCSV),
+	scheduleSuite("solve_rate", "solve_rate", noCalendar(calc.SolveRateV1), calc.RenderSolveResponseJSON, calc.RenderScheduleCSV),
+	scheduleSuite("solve_term", "solve_term", noCalendar(calc.SolveTermV1), calc.RenderSolveResponseJSON, calc.RenderScheduleCSV),
+	summarySuite("xirr", "xirr", calc.XirrV1, calc.RenderXirrResponseJSON),
+}
+
+// errInvalidJSON marks a request the calculator cannot decode; in the demo
+// such a case is a broken fixture, not an expected-fail case.
+var errInvalidJSON = errors.New("invalid JSON")
+
+// decodeStrict decodes exactly one JSON value into Req, rejecting unknown
+// fields and trailing tokens.
+func decodeStrict[Req any](body []byte) (Req, error) {
+	var req, zero Req
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		return zero, errInvalidJSON
+	}
+	var extra any
+	if err := dec.Decode(&extra); err != io.EOF {
+		return zero, errInvalidJSON
+	}
+	return req, nil
+}
+
+// scheduleSuite builds a suite for a calculator with a JSON response and a
+// CSV schedule.
+func scheduleSuite[Req, Resp, Row any](name, dir string, compute func(Req, *calc.HolidayCalendar) (Resp, []Row, error), renderResp func(Resp) ([]byte, error), renderRows func([]Row) ([]byte, error)) suite {
+	return suite{
+		name: name,
+		dir:  dir,
+		compute: func(cal *calc.HolidayCalendar, body []byte) ([]outFile, error) {
+			req, err := decodeStrict[Req](body)
+			if err != nil {
+				return nil, err
+			}
+			resp, rows, err := compute(req, cal)
+			if err != nil {
+				return nil, err
+			}
+			respJSON, err := renderResp(resp)
+			if err != nil {
+				return nil, fmt.Errorf("render response: %w", err)
+			}
+			schedCSV, err := renderRows(rows)
+			if err != nil {
+				return nil, fmt.Errorf("render schedule: %w", err)
+			}
+			return []outFile{{"response.json", respJSON}, {"schedule.csv", schedCSV}}, nil
+		},
+	}
+}
+
+// summarySuite builds a suite for a calculator with only a JSON response.
+func summarySuite[Req, Resp any](name, dir string, compute func(Req) (Resp, error), render func(Resp) ([]byte, error)) suite {
+	return suite{
+		name: name,
+		dir:  dir,
+		compute: func(_ *calc.HolidayCalendar, body []byte) ([]outFile, error) {
+			req, err := decodeStrict[Req](body)
+			if err != nil {
+				return nil, err
+			}
+			resp, err := compute(req)
+			if err != nil {
+				return nil, err
+			}
+			respJSON, err := render(resp)
+			if err != nil {
+				return nil, fmt.Errorf("render response: %w", err)
+			}
+			return []outFile{{"response.json", respJSON}}, nil
+		},
+	}
+}
+
+// noCalendar adapts a calculator that does not roll dates.
+func noCalendar[Req, Resp, Row any](compute func(Req) (Resp, []Row, error)) func(Req, *calc.HolidayCalendar) (Resp, []Row, error) {
+	return func(req Req, _ *calc.HolidayCalendar) (Resp, []Row, error) {
+		return compute(req)
+	}
+}
+
+// caseHolidayCalendar loads the optional per-case holiday calendar for the
+// date_roll rules; a case without holidays.txt gets nil.
+func caseHolidayCalendar(inDir string) (*calc.HolidayCalendar, error) {
+	cal, err := loadHolidayCalendar(filepath.Join(inDir, "holidays.txt"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	return cal, err
+}
+
+// runSuite verifies every case of s and returns the number of cases.
+func runSuite(s suite, fixturesRoot, outRoot string) (int, error) {
+	inRoot := filepath.Join(fixturesRoot, s.dir, "input")
 	entries, err := os.ReadDir(inRoot)
 	if err != nil {
-		return fmt.Errorf("read fixtures: %w", err)
+		return 0, fmt.Errorf("%s: read fixtures: %w", s.name, err)
 	}
 
 	cases := make([]string, 0, len(entries))
@@ -91,95 +377,78 @@ func cmdDemo(args []string) error {
 	}
 	sort.Strings(cases)
 	if len(cases) == 0 {
-		return fmt.Errorf("no fixture cases found under %s", inRoot)
+		return 0, fmt.Errorf("no fixture cases found under %s", inRoot)
 	}
 
 	for _, c := range cases {
-		if err := runCase(*fixtures, *outDir, c); err != nil {
-			return err
+		if err := runCase(s, fixturesRoot, outRoot, c); err != nil {
+			return 0, err
 		}
 	}
-
-	fmt.Fprintf(os.Stdout, "OK: demo outputs match fixtures (%d case(s))\n", len(cases))
-	return nil
+	return len(cases), nil
 }
 
-func runCase(fixturesRoot, outRoot, caseName string) error {
-	reqPath := filepath.Join(fixturesRoot, "input", caseName, "request.json")
-	b, err := os.ReadFile(reqPath)
-	if err != nil {
-		return fmt.Errorf("%s: read request.json: %w", caseName, err)
-	}
-	var req calc.AmortizeRequestV1
-	dec := json.NewDecoder(bytes.NewReader(b))
-	dec.DisallowUnknownFields()
-	if err := dec.Decode(&req); err != nil {
-		return fmt.Errorf("%s: invalid JSON", caseName)
+func runCase(s suite, fixturesRoot, outRoot, caseName string) error {
+	label := caseName
+	if s.dir != "" {
+		label = s.dir + "/" + caseName
 	}
-	var extra any
-	if err := dec.Decode(&extra); err != io.EOF {
-		return fmt.Errorf("%s: invalid JSON", caseName)
+	inDir := filepath.Join(fixturesRoot, s.dir, "input", caseName)
+	b, err := os.ReadFile(filepath.Join(inDir, "request.json"))
+	if err != nil {
+		return fmt.Errorf("%s: read request.json: %w", label, err)
 	}
 
-	expectedDir := filepath.Join(fixturesRoot, "expected", caseName)
-	outDir := filepath.Join(outRoot, caseName)
+	expectedDir := filepath.Join(fixturesRoot, s.dir, "expected", caseName)
+	outDir := filepath.Join(outRoot, s.dir, caseName)
 	if err := os.MkdirAll(outDir, 0o755); err != nil {
-		return fmt.Errorf("%s: mkdir out: %w", caseName, err)
+		return fmt.Errorf("%s: mkdir out: %w", label, err)
+	}
+
+	cal, err := caseHolidayCalendar(inDir)
+	if err != nil {
+		return fmt.Errorf("%s: %w", label, err)
+	}
+	files, errCalc := s.compute(cal, b)
+	if errors.Is(errCalc, errInvalidJSON) {
+		return fmt.Errorf("%s: invalid JSON", label)
 	}
 
 	wantErrPath := filepath.Join(expectedDir, "error.txt")
 	if wantErr, errRead := os.ReadFile(wantErrPath); errRead == nil {
 		// expected-fail case
-		_, _, errCalc := calc.AmortizeV1(req)
 		if errCalc == nil {
-			return fmt.Errorf("%s: expected error, got nil", caseName)
+			return fmt.Errorf("%s: expected error, got nil", label)
 		}
 		gotErr := []byte(fmt.Sprintf("error: %s\n", errCalc.Error()))
 		if err := fsutil.AtomicWriteFile(filepath.Join(outDir, "error.txt"), gotErr, 0o644); err != nil {
-			return fmt.Errorf("%s: write error.txt: %w", caseName, err)
+			return fmt.Errorf("%s: write error.txt: %w", label, err)
 		}
 		if !bytes.Equal(gotErr, wantErr) {
-			return fmt.Errorf("%s: error.txt mismatch", caseName)
+			return fmt.Errorf("%s: error.txt mismatch", label)
 		}
 		return nil
 	}
-
-	resp, sched, err := calc.AmortizeV1(req)
-	if err != nil {
-		return fmt.Errorf("%s: %w", caseName, err)
-	}
-
-	respJSON, err := calc.RenderResponseJSON(resp)
-	if err != nil {
-		return fmt.Errorf("%s: render response: %w", caseName, err)
-	}
-	schedCSV, err := calc.RenderScheduleCSV(sched)
-	if err != nil {
-		return fmt.Errorf("%s: render schedule: %w", caseName, err)
+	if errCalc != nil {
+		return fmt.Errorf("%s: %w", label, errCalc)
 	}
 
 	// write outputs (for humans)
-	if err := fsutil.AtomicWriteFile(filepath.Join(outDir, "response.json"), respJSON, 0o644); err != nil {
-		return fmt.Errorf("%s: write response.json: %w", caseName, err)
-	}
-	if err := fsutil.AtomicWriteFile(filepath.Join(outDir, "schedule.csv"), schedCSV, 0o644); err != nil {
-		return fmt.Errorf("%s: write schedule.csv: %w", caseName, err)
+	for _, f := range files {
+		if err := fsutil.AtomicWriteFile(filepath.Join(outDir, f.name), f.data, 0o644); err != nil {
+			return fmt.Errorf("%s: write %s: %w", label, f.name, err)
+		}
 	}
 
 	// verify against fixtures
-	wantResp, err := os.ReadFile(filepath.Join(expectedDir, "response.json"))
-	if err != nil {
-		return fmt.Errorf("%s: read expected response.json: %w", caseName, err)
-	}
-	wantSched, err := os.ReadFile(filepath.Join(expectedDir, "schedule.csv"))
-	if err != nil {
-		return fmt.Errorf("%s: read expected schedule.csv: %w", caseName, err)
-	}
-	if !bytes.Equal(respJSON, wantResp) {
-		return fmt.Errorf("%s: response.json mismatch", caseName)
-	}
-	if !bytes.Equal(schedCSV, wantSched) {
-		return fmt.Errorf("%s: schedule.csv mismatch", caseName)
+	for _, f := range files {
+		want, err := os.ReadFile(filepath.Join(expectedDir, f.name))
+		if err != nil {
+			return fmt.Errorf("%s: read expected %s: %w", label, f.name, err)
+		}
+		if !bytes.Equal(f.data, want) {
+			return fmt.Errorf("%s: %s mismatch", label, f.name)
+		}
 	}
 
 	return nil
@@ -189,11 +458,30 @@ func cmdServe(args []string) error {
 	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
 	fs.SetOutput(os.Stderr)
 	addr := fs.String("addr", "127.0.0.1:8080", "Listen address")
+	holidays := fs.String("holidays", "", "Holiday calendar file (one YYYY-MM-DD per line)")
 	if err := fs.Parse(args); err != nil {
 		return err
 	}
 
-	srv := api.NewServer(*addr)
+	var opts api.Options
+	if *holidays != "" {
+		cal, err := loadHolidayCalendar(*holidays)
+		if err != nil {
+			return err
+		}
+		opts.Holidays = cal
+	}
+
+	srv := api.NewServer(*addr, opts)
 	fmt.Fprintf(os.Stdout, "Listening on http://%s\n", *addr)
 	return srv.ListenAndServe()
 }
+
+func loadHolidayCalendar(path string) (*calc.HolidayCalendar, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open holidays: %w", err)
+	}
+	defer f.Close()
+	return calc.ParseHolidayCalendar(f)
+}
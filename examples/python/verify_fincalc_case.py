@@ -109,19 +109,33 @@ def main() -> None:
     # Contract echoes
     assert resp.get("schema_version") == "v1", "schema_version mismatch"
     assert resp.get("calculator") == "amortize", "calculator mismatch"
-    for k in ("principal_cents", "annual_rate_bps", "term_months", "start_date"):
+    for k in ("principal_cents", "annual_rate_bps", "term_months"):
         assert resp.get(k) == req.get(k), f"echo field mismatch: {k}"
-
-    term = int(resp["term_months"])
-    assert len(rows) == term, "schedule row count != term_months"
-    assert [r.period for r in rows] == list(range(1, term + 1)), "period sequence mismatch"
+    first_payment = req.get("first_payment_date", req.get("start_date"))
+    assert resp.get("start_date") == first_payment, "echo field mismatch: start_date"
+
+    scheduled = int(resp.get("num_payments", resp["term_months"]))
+    prepay = resp.get("prepayment")
+    n_rows = scheduled
+    if prepay is not None:
+        n_rows = int(prepay["payoff_payments"])
+        assert int(prepay["payments_saved"]) == scheduled - n_rows, "payments_saved mismatch"
+    assert len(rows) == n_rows, "schedule row count mismatch"
+    assert [r.period for r in rows] == list(range(1, n_rows + 1)), "period sequence mismatch"
+    for r in rows:
+        assert r.payment_cents == r.principal_cents + r.interest_cents, f"period {r.period}: payment != principal + interest"
 
     # Totals and invariants
     principal_sum = sum(r.principal_cents for r in rows)
     interest_sum = sum(r.interest_cents for r in rows)
     payment_sum = sum(r.payment_cents for r in rows)
 
-    assert principal_sum == int(resp["principal_cents"]), "principal sum mismatch"
+    # Capitalized odd-period interest is amortized like principal.
+    odd = resp.get("odd_period")
+    want_principal = int(resp["principal_cents"])
+    if odd is not None and odd["treatment"] == "capitalize":
+        want_principal += int(odd["odd_interest_cents"])
+    assert principal_sum == want_principal, "principal sum mismatch"
     assert interest_sum == int(resp["total_interest_cents"]), "interest sum mismatch"
     assert payment_sum == int(resp["total_paid_cents"]), "total paid mismatch"
 
@@ -129,8 +143,20 @@ def main() -> None:
 
     pay = int(resp["payment_cents"])
     last_pay = int(resp["last_payment_cents"])
-    for r in rows[:-1]:
-        assert r.payment_cents == pay, "non-last payment must equal payment_cents"
+    if prepay is None:
+        for r in rows[:-1]:
+            want = pay
+            if "interest_only_months" in resp and r.principal_cents == 0:
+                want = r.interest_cents
+            if r.period == 1 and odd is not None and odd["treatment"] == "add_to_first_payment":
+                want += int(odd["odd_interest_cents"])
+            assert r.payment_cents == want, "non-last payment must equal payment_cents"
+    else:
+        # Prepaid principal rides on top of the scheduled payment.
+        for r in rows[:-1]:
+            assert r.payment_cents >= pay, "payment below payment_cents"
+        interest_saved = int(prepay["contractual_interest_cents"]) - interest_sum
+        assert int(prepay["interest_saved_cents"]) == interest_saved, "interest_saved_cents mismatch"
     assert rows[-1].payment_cents == last_pay, "last payment must equal last_payment_cents"
 
     # Optional: byte-for-byte compare to checked-in goldens.
@@ -10,8 +10,20 @@ import (
 	"github.com/nicholaskarlson/proof-first-finance-calc/internal/calc"
 )
 
-// Handler returns an http.Handler serving the v1 API.
+// Options configures the API handler.
+type Options struct {
+	// Holidays is the business-day calendar used by the date_roll rules.
+	// Nil treats only weekends as non-business days.
+	Holidays *calc.HolidayCalendar
+}
+
+// Handler returns an http.Handler serving the v1 API with default options.
 func Handler() http.Handler {
+	return HandlerWithOptions(Options{})
+}
+
+// HandlerWithOptions returns an http.Handler serving the v1 API.
+func HandlerWithOptions(opts Options) http.Handler {
 	mux := http.NewServeMux()
 
 	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
@@ -20,75 +32,171 @@ func Handler() http.Handler {
 		_, _ = w.Write([]byte("ok\n"))
 	})
 
-	mux.HandleFunc("/v1/amortize", func(w http.ResponseWriter, r *http.Request) {
+	amortize := func(req calc.AmortizeRequestV1) (calc.AmortizeResponseV1, []calc.ScheduleRow, error) {
+		return calc.AmortizeV1WithCalendar(req, opts.Holidays)
+	}
+	mux.HandleFunc("/v1/amortize", jsonHandler(amortize, calc.RenderResponseJSON))
+	mux.HandleFunc("/v1/amortize/schedule.csv", csvHandler(amortize, calc.RenderScheduleCSV))
+
+	apr := func(req calc.AprRequestV1) (calc.AprResponseV1, []calc.ScheduleRow, error) {
+		return calc.AprV1WithCalendar(req, opts.Holidays)
+	}
+	mux.HandleFunc("/v1/apr", jsonHandler(apr, calc.RenderAprResponseJSON))
+	mux.HandleFunc("/v1/apr/schedule.csv", csvHandler(apr, calc.RenderScheduleCSV))
+
+	arm := func(req calc.ArmRequestV1) (calc.ArmResponseV1, []calc.ArmScheduleRow, error) {
+		return calc.ArmV1WithCalendar(req, opts.Holidays)
+	}
+	mux.HandleFunc("/v1/arm", jsonHandler(arm, calc.RenderArmResponseJSON))
+	mux.HandleFunc("/v1/arm/schedule.csv", csvHandler(arm, calc.RenderArmScheduleCSV))
+
+	mux.HandleFunc("/v1/bond/price", jsonHandler(calc.BondPriceV1, calc.RenderBondResponseJSON))
+	mux.HandleFunc("/v1/bond/price/schedule.csv", csvHandler(calc.BondPriceV1, calc.RenderBondCashFlowsCSV))
+	mux.HandleFunc("/v1/bond/yield", jsonHandler(calc.BondYieldV1, calc.RenderBondResponseJSON))
+	mux.HandleFunc("/v1/bond/yield/schedule.csv", csvHandler(calc.BondYieldV1, calc.RenderBondCashFlowsCSV))
+
+	mux.HandleFunc("/v1/credit_card", jsonHandler(calc.CreditCardV1, calc.RenderCreditCardResponseJSON))
+	mux.HandleFunc("/v1/credit_card/schedule.csv", csvHandler(calc.CreditCardV1, calc.RenderCreditCardScheduleCSV))
+
+	mux.HandleFunc("/v1/deferment", jsonHandler(calc.DefermentV1, calc.RenderDefermentResponseJSON))
+	mux.HandleFunc("/v1/deferment/schedule.csv", csvHandler(calc.DefermentV1, calc.RenderDefermentScheduleCSV))
+
+	mux.HandleFunc("/v1/depreciation", jsonHandler(calc.DepreciationV1, calc.RenderDepreciationResponseJSON))
+	mux.HandleFunc("/v1/depreciation/schedule.csv", csvHandler(calc.DepreciationV1, calc.RenderDepreciationScheduleCSV))
+
+	mux.HandleFunc("/v1/npv", summaryHandler(calc.NpvV1, calc.RenderNpvResponseJSON))
+	mux.HandleFunc("/v1/irr", summaryHandler(calc.IrrV1, calc.RenderIrrResponseJSON))
+	mux.HandleFunc("/v1/xirr", summaryHandler(calc.XirrV1, calc.RenderXirrResponseJSON))
+
+	mux.HandleFunc("/v1/graduated", jsonHandler(calc.GraduatedV1, calc.RenderGraduatedResponseJSON))
+	mux.HandleFunc("/v1/graduated/schedule.csv", csvHandler(calc.GraduatedV1, calc.RenderGraduatedScheduleCSV))
+
+	mux.HandleFunc("/v1/negam", jsonHandler(calc.NegAmV1, calc.RenderNegAmResponseJSON))
+	mux.HandleFunc("/v1/negam/schedule.csv", csvHandler(calc.NegAmV1, calc.RenderNegAmScheduleCSV))
+
+	payoff := func(req calc.PayoffRequestV1) (calc.PayoffResponseV1, []calc.ScheduleRow, error) {
+		return calc.PayoffV1WithCalendar(req, opts.Holidays)
+	}
+	mux.HandleFunc("/v1/payoff", jsonHandler(payoff, calc.RenderPayoffResponseJSON))
+	mux.HandleFunc("/v1/payoff/schedule.csv", csvHandler(payoff, calc.RenderScheduleCSV))
+
+	piti := func(req calc.PitiRequestV1) (calc.PitiResponseV1, []calc.PitiRow, error) {
+		return calc.PitiV1WithCalendar(req, opts.Holidays)
+	}
+	mux.HandleFunc("/v1/piti", jsonHandler(piti, calc.RenderPitiResponseJSON))
+	mux.HandleFunc("/v1/piti/schedule.csv", csvHandler(piti, calc.RenderPitiScheduleCSV))
+
+	mux.HandleFunc("/v1/precomputed", jsonHandler(calc.PrecomputedV1, calc.RenderPrecomputedResponseJSON))
+	mux.HandleFunc("/v1/precomputed/schedule.csv", csvHandler(calc.PrecomputedV1, calc.RenderPrecomputedScheduleCSV))
+
+	refinance := func(req calc.RefinanceRequestV1) (calc.RefinanceResponseV1, []calc.RefinanceRow, error) {
+		return calc.RefinanceV1WithCalendar(req, opts.Holidays)
+	}
+	mux.HandleFunc("/v1/refinance", jsonHandler(refinance, calc.RenderRefinanceResponseJSON))
+	mux.HandleFunc("/v1/refinance/schedule.csv", csvHandler(refinance, calc.RenderRefinanceScheduleCSV))
+
+	mux.HandleFunc("/v1/savings/future_value", jsonHandler(calc.FutureValueV1, calc.RenderFutureValueResponseJSON))
+	mux.HandleFunc("/v1/savings/future_value/schedule.csv", csvHandler(calc.FutureValueV1, calc.RenderSavingsScheduleCSV))
+	mux.HandleFunc("/v1/savings/annuity", jsonHandler(calc.AnnuityV1, calc.RenderAnnuityResponseJSON))
+	mux.HandleFunc("/v1/savings/annuity/schedule.csv", csvHandler(calc.AnnuityV1, calc.RenderSavingsScheduleCSV))
+	mux.HandleFunc("/v1/savings/sinking_fund", jsonHandler(calc.SinkingFundV1, calc.RenderSinkingFundResponseJSON))
+	mux.HandleFunc("/v1/savings/sinking_fund/schedule.csv", csvHandler(calc.SinkingFundV1, calc.RenderSavingsScheduleCSV))
+
+	mux.HandleFunc("/v1/simple_interest", jsonHandler(calc.SimpleInterestV1, calc.RenderSimpleInterestResponseJSON))
+	mux.HandleFunc("/v1/simple_interest/schedule.csv", csvHandler(calc.SimpleInterestV1, calc.RenderSimpleInterestLedgerCSV))
+
+	mux.HandleFunc("/v1/solve/term", jsonHandler(calc.SolveTermV1, calc.RenderSolveResponseJSON))
+	mux.HandleFunc("/v1/solve/term/schedule.csv", csvHandler(calc.SolveTermV1, calc.RenderScheduleCSV))
+	mux.HandleFunc("/v1/solve/rate", jsonHandler(calc.SolveRateV1, calc.RenderSolveResponseJSON))
+	mux.HandleFunc("/v1/solve/rate/schedule.csv", csvHandler(calc.SolveRateV1, calc.RenderScheduleCSV))
+	mux.HandleFunc("/v1/solve/principal", jsonHandler(calc.SolvePrincipalV1, calc.RenderSolveResponseJSON))
+	mux.HandleFunc("/v1/solve/principal/schedule.csv", csvHandler(calc.SolvePrincipalV1, calc.RenderScheduleCSV))
+
+	return mux
+}
+
+// jsonHandler serves POST requests for a scheduling calculator's JSON
+// response.
+func jsonHandler[Req, Resp, Row any](compute func(Req) (Resp, []Row, error), render func(Resp) ([]byte, error)) http.HandlerFunc {
+	return summaryHandler(func(req Req) (Resp, error) {
+		resp, _, err := compute(req)
+		return resp, err
+	}, render)
+}
+
+// summaryHandler serves POST requests for a calculator's JSON response.
+func summaryHandler[Req, Resp any](compute func(Req) (Resp, error), render func(Resp) ([]byte, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
 			methodNotAllowed(w)
 			return
 		}
-		req, err := decodeRequest(r)
+		req, err := decodeRequest[Req](r)
 		if err != nil {
 			badRequest(w, err.Error())
 			return
 		}
-		resp, _, err := calc.AmortizeV1(req)
+		resp, err := compute(req)
 		if err != nil {
 			badRequest(w, err.Error())
 			return
 		}
-		b, err := calc.RenderResponseJSON(resp)
+		b, err := render(resp)
 		if err != nil {
 			internalError(w)
 			return
 		}
-		w.Header().Set("Content-Type", "application/json; charset=utf-8")
-		w.Header().Set("Cache-Control", "no-store")
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write(b)
-	})
+		writeBody(w, "application/json; charset=utf-8", b)
+	}
+}
 
-	mux.HandleFunc("/v1/amortize/schedule.csv", func(w http.ResponseWriter, r *http.Request) {
+// csvHandler serves POST requests for a calculator's CSV schedule.
+func csvHandler[Req, Resp, Row any](compute func(Req) (Resp, []Row, error), render func([]Row) ([]byte, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
 			methodNotAllowed(w)
 			return
 		}
-		req, err := decodeRequest(r)
+		req, err := decodeRequest[Req](r)
 		if err != nil {
 			badRequest(w, err.Error())
 			return
 		}
-		_, sched, err := calc.AmortizeV1(req)
+		_, sched, err := compute(req)
 		if err != nil {
 			badRequest(w, err.Error())
 			return
 		}
-		b, err := calc.RenderScheduleCSV(sched)
+		b, err := render(sched)
 		if err != nil {
 			internalError(w)
 			return
 		}
-		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
-		w.Header().Set("Cache-Control", "no-store")
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write(b)
-	})
+		writeBody(w, "text/csv; charset=utf-8", b)
+	}
+}
 
-	return mux
+func writeBody(w http.ResponseWriter, contentType string, b []byte) {
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(b)
 }
 
-func decodeRequest(r *http.Request) (calc.AmortizeRequestV1, error) {
+func decodeRequest[Req any](r *http.Request) (Req, error) {
 	// Tight, stable failures. DisallowUnknownFields gives better signals for users,
 	// but we don't want to lock tests to Go's JSON error text. So we keep messages
 	// minimal + stable.
 	dec := json.NewDecoder(r.Body)
 	dec.DisallowUnknownFields()
-	var req calc.AmortizeRequestV1
+	var req, zero Req
 	if err := dec.Decode(&req); err != nil {
-		return calc.AmortizeRequestV1{}, fmt.Errorf("invalid JSON")
+		return zero, fmt.Errorf("invalid JSON")
 	}
 	// Reject trailing tokens.
 	var extra any
 	if err := dec.Decode(&extra); err != io.EOF {
-		return calc.AmortizeRequestV1{}, fmt.Errorf("invalid JSON")
+		return zero, fmt.Errorf("invalid JSON")
 	}
 	return req, nil
 }
@@ -113,10 +221,10 @@ func internalError(w http.ResponseWriter) {
 }
 
 // NewServer constructs an http.Server with sensible timeouts.
-func NewServer(addr string) *http.Server {
+func NewServer(addr string, opts Options) *http.Server {
 	return &http.Server{
 		Addr:              addr,
-		Handler:           Handler(),
+		Handler:           HandlerWithOptions(opts),
 		ReadHeaderTimeout: 5 * time.Second,
 		ReadTimeout:       10 * time.Second,
 		WriteTimeout:      10 * time.Second,
@@ -8,47 +8,355 @@ import (
 )
 
 const (
-	calcNameV1   = "amortize"
-	schemaV1     = "v1"
-	bpsDenom     = int64(10000)
-	monthsPerYr  = int64(12)
-	monthlyDenom = bpsDenom * monthsPerYr
+	calcNameV1  = "amortize"
+	schemaV1    = "v1"
+	bpsDenom    = int64(10000)
+	monthsPerYr = int64(12)
+)
+
+// Request bounds enforced by validateReq. Within them every intermediate
+// product (balance * rate) and every schedule total fits in int64 cents.
+const (
+	MaxPrincipalCents = int64(10_000_000_000_000) // $100 billion
+	MaxAnnualRateBps  = int64(100_000)            // 1000.00%
+	MaxTermMonths     = 1200                      // 100 years
 )
 
 // AmortizeV1 computes a deterministic amortization schedule using:
 // - integer cents for all money
 // - basis points for annual nominal rate
-// - monthly rate r = annual_rate / 12
+// - periodic rate r = annual_rate / payments_per_year for the payment
+// - interest accrued per the request's day-count convention (default 30/360)
 // - interest rounded half-up to cents each period
 // - payment rounded half-up to cents
 // - last payment adjusted to bring balance to exactly zero
+//
+// The final contractual period always sweeps the remaining balance, so any
+// residue from a payment that rounded down is collected there.
+//
+// Under an actual day count the level payment is solved against the
+// actual-day accrual instead. A long period whose interest exceeds the level
+// payment amortizes negatively: its principal is negative and the shortfall
+// is added to the balance.
+//
+// During the first interest_only_months the rows pay interest only; the level
+// payment is computed to amortize the balance over the remaining payments.
+//
+// When amortization_months exceeds term_months the payment is computed over
+// the longer amortization and the final row carries the balloon.
+//
+// With funding_date and first_payment_date, interest on the odd days of an
+// irregular first period is added to the first payment or capitalized.
+//
+// Optional prepayments (recurring extra principal and dated lump sums) are
+// applied on top of the scheduled payment. The scheduled payment is not
+// recast, so the loan pays off early and the schedule is shortened.
 func AmortizeV1(req AmortizeRequestV1) (AmortizeResponseV1, []ScheduleRow, error) {
+	return AmortizeV1WithCalendar(req, nil)
+}
+
+// AmortizeV1WithCalendar is AmortizeV1 with a holiday calendar for the
+// business-day date-roll rules. A nil calendar treats only weekends as
+// non-business days.
+func AmortizeV1WithCalendar(req AmortizeRequestV1, cal *HolidayCalendar) (AmortizeResponseV1, []ScheduleRow, error) {
 	if err := validateReq(req); err != nil {
 		return AmortizeResponseV1{}, nil, err
 	}
-	start, _ := time.Parse("2006-01-02", req.StartDate)
-	start = start.UTC()
+	plan := newAmortizePlan(req, cal)
+	odd, err := resolveOddPeriod(&plan)
+	if err != nil {
+		return AmortizeResponseV1{}, nil, err
+	}
 
-	pmt := scheduledPaymentCents(req.PrincipalCents, req.AnnualRateBps, req.TermMonths)
-	bal := req.PrincipalCents
+	pmt, err := levelPaymentCents(plan)
+	if err != nil {
+		return AmortizeResponseV1{}, nil, err
+	}
+	extra, err := extraPrincipalByPeriod(plan)
+	if err != nil {
+		return AmortizeResponseV1{}, nil, err
+	}
+	rows, prepaid, err := amortizeRows(plan, pmt, extra)
+	if err != nil {
+		return AmortizeResponseV1{}, nil, err
+	}
+	totalInt, totalPaid, err := scheduleTotals(rows)
+	if err != nil {
+		return AmortizeResponseV1{}, nil, err
+	}
 
-	rows := make([]ScheduleRow, 0, req.TermMonths)
-	var totalInt, totalPaid int64
+	resp := AmortizeResponseV1{
+		SchemaVersion:      schemaV1,
+		Calculator:         calcNameV1,
+		PrincipalCents:     req.PrincipalCents,
+		AnnualRateBps:      req.AnnualRateBps,
+		TermMonths:         req.TermMonths,
+		StartDate:          plan.start.Format("2006-01-02"),
+		PaymentFrequency:   req.PaymentFrequency,
+		DateRoll:           req.DateRoll,
+		DayCount:           req.DayCount,
+		PaymentCents:       pmt,
+		LastPaymentCents:   rows[len(rows)-1].PaymentCents,
+		TotalInterestCents: totalInt,
+		TotalPaidCents:     totalPaid,
+		OddPeriod:          odd,
+	}
+	if req.PaymentFrequency != "" {
+		resp.NumPayments = plan.n
+	}
+	if req.AmortizationMonths != 0 {
+		resp.AmortizationMonths = req.AmortizationMonths
+		// Prepayments that retire the loan early leave no balloon.
+		if len(rows) == plan.n {
+			resp.BalloonPaymentCents = resp.LastPaymentCents
+		}
+	}
+	resp.InterestOnlyMonths = req.InterestOnlyMonths
+
+	if extra != nil {
+		// Re-run without prepayments to measure what they saved.
+		contractual, _, err := amortizeRows(plan, pmt, nil)
+		if err != nil {
+			return AmortizeResponseV1{}, nil, err
+		}
+		contractualInt, _, err := scheduleTotals(contractual)
+		if err != nil {
+			return AmortizeResponseV1{}, nil, err
+		}
+		resp.Prepayment = &PrepaymentSummaryV1{
+			ExtraPrincipalCents:      req.ExtraPrincipalCents,
+			TotalPrepaidCents:        prepaid,
+			ContractualInterestCents: contractualInt,
+			InterestSavedCents:       contractualInt - totalInt,
+		}
+		payoff, saved := len(rows), plan.n-len(rows)
+		if plan.freq.months == 1 {
+			resp.Prepayment.PayoffMonths, resp.Prepayment.MonthsSaved = &payoff, &saved
+		}
+		if req.PaymentFrequency != "" {
+			resp.Prepayment.PayoffPayments, resp.Prepayment.PaymentsSaved = &payoff, &saved
+		}
+	}
+	return resp, rows, nil
+}
+
+// amortizePlan is a validated request resolved into schedule terms.
+type amortizePlan struct {
+	req    AmortizeRequestV1
+	start  time.Time // first payment date (unadjusted)
+	freq   paymentFrequency
+	n      int // number of scheduled payments
+	amortN int // number of payments the level payment is computed over
+	ioN    int // leading interest-only payments
+	cal    *HolidayCalendar
+
+	balance     int64 // amount amortized: principal plus any capitalized odd interest
+	oddInterest int64 // odd-days interest added to the first payment
+}
+
+// newAmortizePlan resolves a request that has already passed validateReq.
+func newAmortizePlan(req AmortizeRequestV1, cal *HolidayCalendar) amortizePlan {
+	start, _ := time.Parse("2006-01-02", firstPaymentDate(req))
+	freq, _ := lookupFrequency(req.PaymentFrequency)
+	n, _ := freq.payments(req.TermMonths)
+	amortN := n
+	if req.AmortizationMonths != 0 {
+		amortN, _ = freq.payments(req.AmortizationMonths)
+	}
+	ioN, _ := freq.payments(req.InterestOnlyMonths)
+	return amortizePlan{req: req, start: start.UTC(), freq: freq, n: n, amortN: amortN, ioN: ioN, cal: cal, balance: req.PrincipalCents}
+}
+
+// firstPaymentField names the request field firstPaymentDate reads, for
+// error messages.
+func firstPaymentField(req AmortizeRequestV1) string {
+	if req.FirstPaymentDate != "" {
+		return "first_payment_date"
+	}
+	return "start_date"
+}
+
+// firstPaymentDate returns first_payment_date when set, else start_date.
+func firstPaymentDate(req AmortizeRequestV1) string {
+	if req.FirstPaymentDate != "" {
+		return req.FirstPaymentDate
+	}
+	return req.StartDate
+}
+
+// resolveOddPeriod computes odd first-period interest and folds it into the
+// plan. It returns nil when the request has no funding_date.
+func resolveOddPeriod(plan *amortizePlan) (*OddPeriodV1, error) {
+	req := plan.req
+	if req.FundingDate == "" {
+		return nil, nil
+	}
+	funding, _ := time.Parse("2006-01-02", req.FundingDate)
+	regularStart := plan.dueDate(0)
+	interest, err := oddInterestCents(req.PrincipalCents, req.AnnualRateBps, req.DayCount, funding, regularStart)
+	if err != nil {
+		return nil, err
+	}
+	treatment := req.OddInterest
+	if treatment == "" {
+		treatment = OddInterestAddToFirstPayment
+	}
+	if treatment == OddInterestCapitalize {
+		if interest < 0 {
+			return nil, errors.New("odd_interest capitalize requires a long first period (funding_date on or before one period before first_payment_date)")
+		}
+		if plan.balance, err = addInt64(plan.balance, interest); err != nil {
+			return nil, err
+		}
+	} else {
+		plan.oddInterest = interest
+	}
+	return &OddPeriodV1{
+		FundingDate:      req.FundingDate,
+		FirstPaymentDate: req.FirstPaymentDate,
+		OddDays:          oddDays(req.DayCount, funding, regularStart),
+		OddInterestCents: interest,
+		Treatment:        treatment,
+	}, nil
+}
+
+// dueDate returns the scheduled date of payment i (1-based) after applying
+// the request's date-roll rule.
+func (p amortizePlan) dueDate(i int) time.Time {
+	return rollDate(p.contractualDate(i), p.req.DateRoll, p.cal)
+}
+
+// contractualDate returns the date of payment i (1-based) before any
+// business-day adjustment.
+func (p amortizePlan) contractualDate(i int) time.Time {
+	switch p.req.DateRoll {
+	case "", DateRollNone:
+		return p.freq.dueDate(p.start, i, addMonthsGo)
+	case DateRollEOM:
+		return p.freq.dueDate(p.start, i, addMonthsEOM)
+	default:
+		return p.freq.dueDate(p.start, i, addMonthsClamped)
+	}
+}
+
+// levelPaymentCents returns the scheduled payment for the plan. Under
+// 30/360 every period accrues at the nominal periodic rate, so the annuity
+// formula applies. Under an actual day count periods accrue unequal
+// interest; the balance at each row is monotone in the payment, so the
+// payment is a binary search over cents for the smallest one whose
+// actual-day schedule retires the balance within the amortization period.
+func levelPaymentCents(plan amortizePlan) (int64, error) {
+	req := plan.req
+	if req.DayCount == "" || req.DayCount == DayCount30360 {
+		return scheduledPaymentCents(plan.balance, req.AnnualRateBps, plan.amortN-plan.ioN, plan.freq.perYear)
+	}
+	// One payment of the balance plus its costliest period's interest
+	// retires the loan, so it bounds the search.
+	hi := plan.balance
+	for i := plan.ioN + 1; i <= plan.amortN; i++ {
+		interest, err := accrualInterestCents(plan.balance, req.AnnualRateBps, plan.freq.perYear, req.DayCount, plan.dueDate(i-1), plan.dueDate(i))
+		if err != nil {
+			return 0, err
+		}
+		if bound, err := addInt64(plan.balance, interest); err != nil {
+			return 0, err
+		} else if bound > hi {
+			hi = bound
+		}
+	}
+	return searchInt64(0, hi, plan.paysOff)
+}
 
-	for i := 1; i <= req.TermMonths; i++ {
-		interest := interestCents(bal, req.AnnualRateBps)
+// paysOff reports whether level payment pmt retires the balance within the
+// plan's amortization period under its day count. The interest-only rows do
+// not change the balance and are skipped. A balance that outgrows int64
+// cents does not pay off.
+func (p amortizePlan) paysOff(pmt int64) (bool, error) {
+	req := p.req
+	bal := p.balance
+	for i := p.ioN + 1; i <= p.amortN; i++ {
+		interest, err := accrualInterestCents(bal, req.AnnualRateBps, p.freq.perYear, req.DayCount, p.dueDate(i-1), p.dueDate(i))
+		if errors.Is(err, errOverflow) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		owed, err := addInt64(bal, interest)
+		if errors.Is(err, errOverflow) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		if owed <= pmt {
+			return true, nil
+		}
+		bal = owed - pmt
+	}
+	return false, nil
+}
+
+// amortizeRows walks the schedule. extra (indexed by period-1) holds any
+// principal paid on top of the scheduled payment; nil means none. The walk
+// stops early once the balance reaches zero. It also returns the total
+// extra principal actually applied.
+func amortizeRows(plan amortizePlan, pmt int64, extra []int64) ([]ScheduleRow, int64, error) {
+	req := plan.req
+	bal := plan.balance
+	rows := make([]ScheduleRow, 0, plan.n)
+	var totalPrepaid int64
+
+	for i := 1; i <= plan.n; i++ {
+		dt := plan.dueDate(i)
+		interest, err := accrualInterestCents(bal, req.AnnualRateBps, plan.freq.perYear, req.DayCount, plan.dueDate(i-1), dt)
+		if err != nil {
+			return nil, 0, err
+		}
 		principal := pmt - interest
 		payThis := pmt
+		if i <= plan.ioN {
+			principal = 0
+			payThis = interest
+		}
+		// Under an actual day count a long period can accrue more than the
+		// level payment; the shortfall is added to the balance (negative
+		// principal), as levelPaymentCents assumed when it solved pmt.
+		if i == 1 && plan.oddInterest != 0 {
+			// Odd-days interest rides on the first payment; it does not
+			// reduce the principal paid.
+			if interest, err = addInt64(interest, plan.oddInterest); err != nil {
+				return nil, 0, err
+			}
+			if payThis, err = addInt64(payThis, plan.oddInterest); err != nil {
+				return nil, 0, err
+			}
+		}
 
-		if principal > bal {
+		// The final contractual period sweeps whatever balance is left,
+		// including residue from a payment that rounded down.
+		if principal > bal || i == plan.n {
 			principal = bal
-			payThis = interest + principal
+			if payThis, err = addInt64(interest, principal); err != nil {
+				return nil, 0, err
+			}
+		}
+
+		if extra != nil {
+			prepaid := extra[i-1]
+			if prepaid > bal-principal {
+				prepaid = bal - principal
+			}
+			principal += prepaid
+			if payThis, err = addInt64(payThis, prepaid); err != nil {
+				return nil, 0, err
+			}
+			totalPrepaid += prepaid
+		}
+		if bal, err = addInt64(bal, -principal); err != nil {
+			return nil, 0, err
 		}
-		bal -= principal
-		totalInt += interest
-		totalPaid += payThis
 
-		dt := start.AddDate(0, i-1, 0)
 		rows = append(rows, ScheduleRow{
 			Period:         i,
 			Date:           dt.Format("2006-01-02"),
@@ -57,58 +365,192 @@ func AmortizeV1(req AmortizeRequestV1) (AmortizeResponseV1, []ScheduleRow, error
 			InterestCents:  interest,
 			BalanceCents:   bal,
 		})
+		if extra != nil && bal == 0 {
+			break
+		}
+	}
+	return rows, totalPrepaid, nil
+}
+
+// scheduleTotals sums interest and payments across rows.
+func scheduleTotals(rows []ScheduleRow) (interest, paid int64, err error) {
+	for _, r := range rows {
+		if interest, err = addInt64(interest, r.InterestCents); err != nil {
+			return 0, 0, err
+		}
+		if paid, err = addInt64(paid, r.PaymentCents); err != nil {
+			return 0, 0, err
+		}
 	}
+	return interest, paid, nil
+}
 
-	resp := AmortizeResponseV1{
-		SchemaVersion:      schemaV1,
-		Calculator:         calcNameV1,
-		PrincipalCents:     req.PrincipalCents,
-		AnnualRateBps:      req.AnnualRateBps,
-		TermMonths:         req.TermMonths,
-		StartDate:          req.StartDate,
-		PaymentCents:       pmt,
-		LastPaymentCents:   rows[len(rows)-1].PaymentCents,
-		TotalInterestCents: totalInt,
-		TotalPaidCents:     totalPaid,
+// extraPrincipalByPeriod maps the request's prepayments onto schedule
+// periods. A lump sum is applied with the first scheduled payment dated on
+// or after the lump sum's date. Returns nil when the request has none.
+func extraPrincipalByPeriod(plan amortizePlan) ([]int64, error) {
+	req := plan.req
+	if req.ExtraPrincipalCents == 0 && len(req.Prepayments) == 0 {
+		return nil, nil
 	}
-	return resp, rows, nil
+	extra := make([]int64, plan.n)
+	for i := range extra {
+		extra[i] = req.ExtraPrincipalCents
+	}
+	for j, p := range req.Prepayments {
+		d, _ := time.Parse("2006-01-02", p.Date)
+		applied := false
+		for i := 1; i <= plan.n && !applied; i++ {
+			if !plan.dueDate(i).Before(d) {
+				sum, err := addInt64(extra[i-1], p.AmountCents)
+				if err != nil {
+					return nil, err
+				}
+				extra[i-1] = sum
+				applied = true
+			}
+		}
+		if !applied {
+			return nil, fmt.Errorf("prepayments[%d].date must be between %s and the final payment date", j, firstPaymentField(req))
+		}
+	}
+	return extra, nil
 }
 
 func validateReq(req AmortizeRequestV1) error {
 	if req.PrincipalCents <= 0 {
 		return errors.New("principal_cents must be > 0")
 	}
+	if req.PrincipalCents > MaxPrincipalCents {
+		return fmt.Errorf("principal_cents must be <= %d", MaxPrincipalCents)
+	}
 	if req.TermMonths <= 0 {
 		return errors.New("term_months must be > 0")
 	}
+	if req.TermMonths > MaxTermMonths {
+		return fmt.Errorf("term_months must be <= %d", MaxTermMonths)
+	}
 	if req.AnnualRateBps < 0 {
 		return errors.New("annual_rate_bps must be >= 0")
 	}
-	if _, err := time.Parse("2006-01-02", req.StartDate); err != nil {
+	if req.AnnualRateBps > MaxAnnualRateBps {
+		return fmt.Errorf("annual_rate_bps must be <= %d", MaxAnnualRateBps)
+	}
+	if req.FirstPaymentDate != "" || req.FundingDate != "" {
+		if req.FirstPaymentDate == "" || req.FundingDate == "" {
+			return errors.New("funding_date and first_payment_date must be set together")
+		}
+		if req.StartDate != "" {
+			return errors.New("start_date must be omitted when first_payment_date is set")
+		}
+	}
+	start, err := time.Parse("2006-01-02", firstPaymentDate(req))
+	if err != nil {
+		if req.FirstPaymentDate != "" {
+			return fmt.Errorf("first_payment_date must be YYYY-MM-DD: %w", err)
+		}
 		return fmt.Errorf("start_date must be YYYY-MM-DD: %w", err)
 	}
+	if req.FundingDate != "" {
+		funding, err := time.Parse("2006-01-02", req.FundingDate)
+		if err != nil {
+			return fmt.Errorf("funding_date must be YYYY-MM-DD: %w", err)
+		}
+		if !funding.Before(start) {
+			return errors.New("funding_date must be before first_payment_date")
+		}
+	}
+	if !validOddInterest(req.OddInterest) {
+		return errors.New("odd_interest must be one of add_to_first_payment, capitalize")
+	}
+	if req.OddInterest != "" && req.FundingDate == "" {
+		return errors.New("odd_interest requires funding_date")
+	}
+	if req.ExtraPrincipalCents < 0 {
+		return errors.New("extra_principal_cents must be >= 0")
+	}
+	if req.ExtraPrincipalCents > MaxPrincipalCents {
+		return fmt.Errorf("extra_principal_cents must be <= %d", MaxPrincipalCents)
+	}
+	if !validDayCount(req.DayCount) {
+		return errors.New("day_count must be one of 30/360, actual/365, actual/360, actual/actual")
+	}
+	freq, ok := lookupFrequency(req.PaymentFrequency)
+	if !ok {
+		return errors.New("payment_frequency must be one of weekly, biweekly, semi_monthly, monthly, quarterly, semi_annual, annual")
+	}
+	if _, ok := freq.payments(req.TermMonths); !ok {
+		return fmt.Errorf("term_months must be a multiple of %d for %s payments", freq.termMultiple(), req.PaymentFrequency)
+	}
+	if req.InterestOnlyMonths != 0 {
+		if req.InterestOnlyMonths < 0 || req.InterestOnlyMonths >= req.TermMonths {
+			return errors.New("interest_only_months must be >= 0 and < term_months")
+		}
+		if _, ok := freq.payments(req.InterestOnlyMonths); !ok {
+			return fmt.Errorf("interest_only_months must be a multiple of %d for %s payments", freq.termMultiple(), req.PaymentFrequency)
+		}
+	}
+	if req.AmortizationMonths != 0 {
+		if req.AmortizationMonths < req.TermMonths {
+			return errors.New("amortization_months must be >= term_months")
+		}
+		if req.AmortizationMonths > MaxTermMonths {
+			return fmt.Errorf("amortization_months must be <= %d", MaxTermMonths)
+		}
+		if _, ok := freq.payments(req.AmortizationMonths); !ok {
+			return fmt.Errorf("amortization_months must be a multiple of %d for %s payments", freq.termMultiple(), req.PaymentFrequency)
+		}
+	}
+	if !validDateRoll(req.DateRoll) {
+		return errors.New("date_roll must be one of none, eom, following, modified_following, preceding")
+	}
+	for i, p := range req.Prepayments {
+		if p.AmountCents <= 0 {
+			return fmt.Errorf("prepayments[%d].amount_cents must be > 0", i)
+		}
+		if p.AmountCents > MaxPrincipalCents {
+			return fmt.Errorf("prepayments[%d].amount_cents must be <= %d", i, MaxPrincipalCents)
+		}
+		d, err := time.Parse("2006-01-02", p.Date)
+		if err != nil {
+			return fmt.Errorf("prepayments[%d].date must be YYYY-MM-DD: %w", i, err)
+		}
+		// The upper bound (the final payment date) depends on date rolling
+		// and is checked when prepayments are mapped onto the schedule.
+		if d.Before(start) {
+			return fmt.Errorf("prepayments[%d].date must be between %s and the final payment date", i, firstPaymentField(req))
+		}
+	}
 	return nil
 }
 
-func interestCents(balanceCents, annualRateBps int64) int64 {
+// interestCents returns one period's interest at the nominal periodic rate
+// annual_rate / perYear.
+func interestCents(balanceCents, annualRateBps, perYear int64) (int64, error) {
 	if annualRateBps == 0 || balanceCents == 0 {
-		return 0
+		return 0, nil
+	}
+	// interest = round_half_up(balance * annual_bps / (10000*perYear))
+	num, err := mulInt64(balanceCents, annualRateBps)
+	if err != nil {
+		return 0, err
 	}
-	// interest = round_half_up(balance * annual_bps / (10000*12))
-	return roundDivHalfUp(balanceCents*annualRateBps, monthlyDenom)
+	return roundDivHalfUp(num, bpsDenom*perYear)
 }
 
-func scheduledPaymentCents(principalCents, annualRateBps int64, termMonths int) int64 {
+// scheduledPaymentCents returns the level payment that retires principalCents
+// over n payments at the nominal periodic rate annual_rate / perYear.
+func scheduledPaymentCents(principalCents, annualRateBps int64, n int, perYear int64) (int64, error) {
 	if annualRateBps == 0 {
 		// round_half_up(P / n)
-		return roundDivHalfUp(principalCents, int64(termMonths))
+		return roundDivHalfUp(principalCents, int64(n))
 	}
 
-	// r = annualRateBps / (10000*12)
-	r := new(big.Rat).SetFrac(big.NewInt(annualRateBps), big.NewInt(monthlyDenom))
+	// r = annualRateBps / (10000*perYear)
+	r := new(big.Rat).SetFrac(big.NewInt(annualRateBps), big.NewInt(bpsDenom*perYear))
 	one := big.NewRat(1, 1)
 	onePlus := new(big.Rat).Add(one, r)
-	pow := powRat(onePlus, termMonths)
+	pow := powRat(onePlus, n)
 
 	// payment = P * r * pow / (pow - 1)
 	num := new(big.Rat).Mul(new(big.Rat).SetInt64(principalCents), r)
@@ -0,0 +1,177 @@
+package calc
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+)
+
+const calcNameAprV1 = "apr"
+
+// MaxAprBps bounds the APR search. An APR above it (e.g. from prepaid
+// finance charges close to the principal) is an error.
+const MaxAprBps = 10 * MaxAnnualRateBps
+
+// unitPeriodDays is the Regulation Z, Appendix J day count of each unit
+// period, used to turn odd days into a fraction of a unit period.
+var unitPeriodDays = map[int64]int64{
+	52: 7,
+	26: 14,
+	24: 15,
+	12: 30,
+	4:  90,
+	2:  180,
+	1:  365,
+}
+
+// AprV1 computes Truth in Lending disclosures for the loan AmortizeV1
+// schedules.
+func AprV1(req AprRequestV1) (AprResponseV1, []ScheduleRow, error) {
+	return AprV1WithCalendar(req, nil)
+}
+
+// AprV1WithCalendar is AprV1 with a holiday calendar for the date-roll
+// rules, as in AmortizeV1WithCalendar.
+//
+// The APR uses the actuarial method of Regulation Z, Appendix J. The unit
+// period is the payment period, consummation is funding_date (or one period
+// before the first payment), and each payment k is discounted over
+// t + k - 1 whole unit periods plus the odd fraction f:
+//
+//	amount financed = sum P_k / ((1 + f*i) * (1 + i)^(t+k-1))
+//
+// t counts whole unit periods stepped back from the first payment without
+// passing consummation; f is the remaining actual days over the unit
+// period's Appendix J days (30 for a month). The APR is i times the unit
+// periods per year.
+//
+// Rounding: apr_bps is the exact APR rounded half-up to a whole basis point.
+// The present value falls as the rate rises, so apr_bps is the largest k
+// whose half-bp boundary k - 1/2 still discounts the payments to at least
+// the amount financed. Each boundary is tested exactly in integers; nothing
+// is iterated to a tolerance.
+func AprV1WithCalendar(req AprRequestV1, cal *HolidayCalendar) (AprResponseV1, []ScheduleRow, error) {
+	aresp, rows, err := AmortizeV1WithCalendar(req.AmortizeRequestV1, cal)
+	if err != nil {
+		return AprResponseV1{}, nil, err
+	}
+
+	var prepaid int64
+	for i, c := range req.PrepaidFinanceCharges {
+		if c.AmountCents < 0 {
+			return AprResponseV1{}, nil, fmt.Errorf("prepaid_finance_charges[%d].amount_cents must be >= 0", i)
+		}
+		if prepaid, err = addInt64(prepaid, c.AmountCents); err != nil {
+			return AprResponseV1{}, nil, err
+		}
+	}
+	if prepaid >= req.PrincipalCents {
+		return AprResponseV1{}, nil, errors.New("prepaid_finance_charges total must be < principal_cents")
+	}
+	financed := req.PrincipalCents - prepaid
+
+	plan := newAmortizePlan(req.AmortizeRequestV1, cal)
+	t, oddDays := aprOddPeriod(plan)
+	unitDays := unitPeriodDays[plan.freq.perYear]
+
+	payments := make([]int64, len(rows))
+	for k, r := range rows {
+		payments[k] = r.PaymentCents
+	}
+	eq := aprEquation{
+		financed: financed,
+		payments: payments,
+		t:        t,
+		f:        big.NewRat(oddDays, unitDays),
+		perYear:  plan.freq.perYear,
+	}
+	// Largest k in [0, MaxAprBps] with pvAtLeastFinanced(k - 1/2).
+	k := sort.Search(int(MaxAprBps), func(k int) bool {
+		return !eq.pvAtLeastFinanced(int64(k) + 1)
+	})
+	if k == int(MaxAprBps) && eq.pvAtLeastFinanced(MaxAprBps+1) {
+		return AprResponseV1{}, nil, fmt.Errorf("apr exceeds %d bps", MaxAprBps)
+	}
+
+	charges := req.PrepaidFinanceCharges
+	if charges == nil {
+		charges = []FinanceChargeV1{}
+	}
+	resp := AprResponseV1{
+		SchemaVersion:              schemaV1,
+		Calculator:                 calcNameAprV1,
+		PrincipalCents:             req.PrincipalCents,
+		AnnualRateBps:              req.AnnualRateBps,
+		PrepaidFinanceCharges:      charges,
+		PrepaidFinanceChargesCents: prepaid,
+		AmountFinancedCents:        financed,
+		FinanceChargeCents:         aresp.TotalPaidCents - financed,
+		TotalOfPaymentsCents:       aresp.TotalPaidCents,
+		AprBps:                     int64(k),
+		NumPayments:                len(rows),
+		UnitPeriodsPerYear:         plan.freq.perYear,
+		UnitPeriodDays:             unitDays,
+		OddUnitPeriods:             t,
+		OddDays:                    oddDays,
+		Amortization:               aresp,
+	}
+	return resp, rows, nil
+}
+
+// aprOddPeriod returns the whole unit periods and the leftover actual days
+// from consummation to the first payment. Without funding_date the loan is
+// consummated one period before the first payment.
+func aprOddPeriod(plan amortizePlan) (t int, days int64) {
+	if plan.req.FundingDate == "" {
+		return 1, 0
+	}
+	funding, _ := time.Parse("2006-01-02", plan.req.FundingDate)
+	for !plan.dueDate(1 - (t + 1)).Before(funding) {
+		t++
+	}
+	return t, daysBetween(funding, plan.dueDate(1-t))
+}
+
+// aprEquation is the Appendix J equation for one loan.
+type aprEquation struct {
+	financed int64
+	payments []int64
+	t        int
+	f        *big.Rat
+	perYear  int64
+}
+
+// pvAtLeastFinanced reports whether the payments, discounted at the annual
+// rate (2*halfBps - 1) / 2 bps (the half-bp boundary below halfBps), are
+// worth at least the amount financed.
+//
+// With i = a/b, x = a + b and f = p/q, multiplying the equation through by
+// q * b^(t+n) * (1+i)^(t+n-1) leaves integers only:
+//
+//	q * sum P_k * x^(n-k) * b^(t+k)  >=  A * (q*b + p*a) * x^(t+n-1)
+func (e aprEquation) pvAtLeastFinanced(halfBps int64) bool {
+	a := big.NewInt(2*halfBps - 1)
+	b := big.NewInt(2 * bpsDenom * e.perYear)
+	x := new(big.Int).Add(a, b)
+	p, q := e.f.Num(), e.f.Denom()
+	n := len(e.payments)
+
+	// Horner over k: s = sum P_k * x^(n-k) * b^(k-1); then scale by b^(t+1).
+	s := new(big.Int)
+	bPow := big.NewInt(1)
+	for _, pk := range e.payments {
+		s.Mul(s, x)
+		s.Add(s, new(big.Int).Mul(big.NewInt(pk), bPow))
+		bPow.Mul(bPow, b)
+	}
+	lhs := new(big.Int).Mul(s, q)
+	lhs.Mul(lhs, new(big.Int).Exp(b, big.NewInt(int64(e.t+1)), nil))
+
+	rhs := new(big.Int).Mul(q, b)
+	rhs.Add(rhs, new(big.Int).Mul(p, a))
+	rhs.Mul(rhs, big.NewInt(e.financed))
+	rhs.Mul(rhs, new(big.Int).Exp(x, big.NewInt(int64(e.t+n-1)), nil))
+	return lhs.Cmp(rhs) >= 0
+}
@@ -0,0 +1,45 @@
+package calc
+
+// AprRequestV1 is the input contract for the v1 APR (Regulation Z)
+// calculator: the amortization inputs of AmortizeRequestV1 plus the prepaid
+// finance charges (points, origination and other fees paid at or before
+// consummation) that reduce the amount financed.
+type AprRequestV1 struct {
+	AmortizeRequestV1
+
+	PrepaidFinanceCharges []FinanceChargeV1 `json:"prepaid_finance_charges,omitempty"`
+}
+
+// FinanceChargeV1 is one named prepaid finance charge.
+type FinanceChargeV1 struct {
+	Name        string `json:"name"`
+	AmountCents int64  `json:"amount_cents"`
+}
+
+// AprResponseV1 is the versioned JSON response for the v1 APR calculator.
+//
+// Notes:
+// - amount_financed_cents = principal_cents - prepaid_finance_charges_cents
+// - total_of_payments_cents is the sum of the schedule's payments
+// - finance_charge_cents = total_of_payments_cents - amount_financed_cents
+// - apr_bps is the actuarial APR rounded half-up to 0.01 percentage point
+// - odd_unit_periods and odd_days describe the time from consummation to the first payment
+type AprResponseV1 struct {
+	SchemaVersion              string            `json:"schema_version"`
+	Calculator                 string            `json:"calculator"`
+	PrincipalCents             int64             `json:"principal_cents"`
+	AnnualRateBps              int64             `json:"annual_rate_bps"`
+	PrepaidFinanceCharges      []FinanceChargeV1 `json:"prepaid_finance_charges"`
+	PrepaidFinanceChargesCents int64             `json:"prepaid_finance_charges_cents"`
+	AmountFinancedCents        int64             `json:"amount_financed_cents"`
+	FinanceChargeCents         int64             `json:"finance_charge_cents"`
+	TotalOfPaymentsCents       int64             `json:"total_of_payments_cents"`
+	AprBps                     int64             `json:"apr_bps"`
+	NumPayments                int               `json:"num_payments"`
+	UnitPeriodsPerYear         int64             `json:"unit_periods_per_year"`
+	UnitPeriodDays             int64             `json:"unit_period_days"`
+	OddUnitPeriods             int               `json:"odd_unit_periods"`
+	OddDays                    int64             `json:"odd_days"`
+
+	Amortization AmortizeResponseV1 `json:"amortization"`
+}
@@ -0,0 +1,261 @@
+package calc
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+const calcNameArmV1 = "arm"
+
+// ArmV1 computes an adjustable-rate mortgage schedule. It is a monthly
+// AmortizeV1 schedule whose rate and payment change over the term:
+// - the rate is fixed for initial_fixed_months payments, then resets
+// - each reset applies index + margin, limited by the caps and floor
+// - the payment is recast at each reset over the remaining term
+//
+// A reset at payment p changes the rate for p's interest and recasts p's
+// payment; the reset's index is looked up as of p's date.
+func ArmV1(req ArmRequestV1) (ArmResponseV1, []ArmScheduleRow, error) {
+	return ArmV1WithCalendar(req, nil)
+}
+
+// ArmV1WithCalendar is ArmV1 with a holiday calendar for the business-day
+// date-roll rules. A nil calendar treats only weekends as non-business
+// days.
+func ArmV1WithCalendar(req ArmRequestV1, cal *HolidayCalendar) (ArmResponseV1, []ArmScheduleRow, error) {
+	if err := validateArmReq(req); err != nil {
+		return ArmResponseV1{}, nil, err
+	}
+	start, _ := time.Parse("2006-01-02", req.StartDate)
+	start = start.UTC()
+	changes := sortedIndexChanges(req.RateChanges)
+
+	rate := req.InitialRateBps
+	maxRate := rate
+	pmt, err := scheduledPaymentCents(req.PrincipalCents, rate, req.TermMonths, monthsPerYr)
+	if err != nil {
+		return ArmResponseV1{}, nil, err
+	}
+	initialPmt := pmt
+	bal := req.PrincipalCents
+
+	rows := make([]ArmScheduleRow, 0, req.TermMonths)
+	resets := make([]ArmResetV1, 0)
+
+	for i := 1; i <= req.TermMonths; i++ {
+		dt := monthlyDueDate(start, i-1, req.DateRoll, cal)
+
+		if isArmReset(req, i) {
+			k := len(resets)
+			index, err := armIndexBps(req, changes, k, dt)
+			if err != nil {
+				return ArmResponseV1{}, nil, err
+			}
+			changeCap := req.PeriodicCapBps
+			if k == 0 {
+				changeCap = req.InitialCapBps
+			}
+			fully := index + req.MarginBps
+			rate = armCappedRate(req, rate, fully, changeCap)
+			if rate > maxRate {
+				maxRate = rate
+			}
+			pmt, err = scheduledPaymentCents(bal, rate, req.TermMonths-i+1, monthsPerYr)
+			if err != nil {
+				return ArmResponseV1{}, nil, err
+			}
+			resets = append(resets, ArmResetV1{
+				Period:          i,
+				Date:            dt.Format("2006-01-02"),
+				IndexBps:        index,
+				FullyIndexedBps: fully,
+				RateBps:         rate,
+				PaymentCents:    pmt,
+			})
+		}
+
+		interest, err := interestCents(bal, rate, monthsPerYr)
+		if err != nil {
+			return ArmResponseV1{}, nil, err
+		}
+		principal := pmt - interest
+		payThis := pmt
+		if principal > bal || i == req.TermMonths {
+			principal = bal
+			if payThis, err = addInt64(interest, principal); err != nil {
+				return ArmResponseV1{}, nil, err
+			}
+		}
+		bal -= principal
+
+		rows = append(rows, ArmScheduleRow{
+			Period:         i,
+			Date:           dt.Format("2006-01-02"),
+			RateBps:        rate,
+			PaymentCents:   payThis,
+			PrincipalCents: principal,
+			InterestCents:  interest,
+			BalanceCents:   bal,
+		})
+	}
+
+	var totalInt, totalPaid int64
+	for _, r := range rows {
+		if totalInt, err = addInt64(totalInt, r.InterestCents); err != nil {
+			return ArmResponseV1{}, nil, err
+		}
+		if totalPaid, err = addInt64(totalPaid, r.PaymentCents); err != nil {
+			return ArmResponseV1{}, nil, err
+		}
+	}
+
+	resp := ArmResponseV1{
+		SchemaVersion:        schemaV1,
+		Calculator:           calcNameArmV1,
+		PrincipalCents:       req.PrincipalCents,
+		TermMonths:           req.TermMonths,
+		StartDate:            req.StartDate,
+		DateRoll:             req.DateRoll,
+		InitialRateBps:       req.InitialRateBps,
+		InitialFixedMonths:   req.InitialFixedMonths,
+		ResetFrequencyMonths: req.ResetFrequencyMonths,
+		MarginBps:            req.MarginBps,
+		InitialCapBps:        req.InitialCapBps,
+		PeriodicCapBps:       req.PeriodicCapBps,
+		LifetimeCapBps:       req.LifetimeCapBps,
+		FloorBps:             req.FloorBps,
+		InitialPaymentCents:  initialPmt,
+		LastPaymentCents:     rows[len(rows)-1].PaymentCents,
+		MaxRateBps:           maxRate,
+		TotalInterestCents:   totalInt,
+		TotalPaidCents:       totalPaid,
+		Resets:               resets,
+	}
+	return resp, rows, nil
+}
+
+// isArmReset reports whether payment i (1-based) starts a new rate period.
+func isArmReset(req ArmRequestV1, i int) bool {
+	k := i - 1 - req.InitialFixedMonths
+	return k >= 0 && k%req.ResetFrequencyMonths == 0
+}
+
+// armIndexBps returns the index for reset k (0-based) dated dt.
+func armIndexBps(req ArmRequestV1, changes []IndexChangeV1, k int, dt time.Time) (int64, error) {
+	if len(req.IndexPathBps) > 0 {
+		if k >= len(req.IndexPathBps) {
+			k = len(req.IndexPathBps) - 1
+		}
+		return req.IndexPathBps[k], nil
+	}
+	day := dt.Format("2006-01-02")
+	// changes is sorted by date; take the last one on or before day.
+	j := sort.Search(len(changes), func(j int) bool { return changes[j].Date > day })
+	if j == 0 {
+		return 0, fmt.Errorf("rate_changes has no index on or before reset date %s", day)
+	}
+	return changes[j-1].IndexBps, nil
+}
+
+// armCappedRate limits the fully indexed rate by the change cap, the
+// lifetime cap and the floor, in that order. With prev between the floor and
+// the ceiling, neither clamp moves the rate past the change cap.
+func armCappedRate(req ArmRequestV1, prev, fully, changeCap int64) int64 {
+	rate := fully
+	if rate > prev+changeCap {
+		rate = prev + changeCap
+	}
+	if rate < prev-changeCap {
+		rate = prev - changeCap
+	}
+	if ceiling := req.InitialRateBps + req.LifetimeCapBps; rate > ceiling {
+		rate = ceiling
+	}
+	if rate < req.FloorBps {
+		rate = req.FloorBps
+	}
+	return rate
+}
+
+// sortedIndexChanges returns a copy of changes sorted by date. ISO dates
+// sort lexically.
+func sortedIndexChanges(changes []IndexChangeV1) []IndexChangeV1 {
+	out := append([]IndexChangeV1(nil), changes...)
+	sort.Slice(out, func(a, b int) bool { return out[a].Date < out[b].Date })
+	return out
+}
+
+func validateArmReq(req ArmRequestV1) error {
+	if req.PrincipalCents <= 0 {
+		return errors.New("principal_cents must be > 0")
+	}
+	if req.PrincipalCents > MaxPrincipalCents {
+		return fmt.Errorf("principal_cents must be <= %d", MaxPrincipalCents)
+	}
+	if req.TermMonths <= 0 {
+		return errors.New("term_months must be > 0")
+	}
+	if req.TermMonths > MaxTermMonths {
+		return fmt.Errorf("term_months must be <= %d", MaxTermMonths)
+	}
+	if _, err := time.Parse("2006-01-02", req.StartDate); err != nil {
+		return fmt.Errorf("start_date must be YYYY-MM-DD: %w", err)
+	}
+	if !validDateRoll(req.DateRoll) {
+		return errors.New("date_roll must be one of none, eom, following, modified_following, preceding")
+	}
+	if req.InitialRateBps < 0 || req.InitialRateBps > MaxAnnualRateBps {
+		return fmt.Errorf("initial_rate_bps must be between 0 and %d", MaxAnnualRateBps)
+	}
+	if req.InitialFixedMonths <= 0 || req.InitialFixedMonths >= req.TermMonths {
+		return errors.New("initial_fixed_months must be > 0 and < term_months")
+	}
+	if req.ResetFrequencyMonths <= 0 {
+		return errors.New("reset_frequency_months must be > 0")
+	}
+	if req.MarginBps < 0 || req.MarginBps > MaxAnnualRateBps {
+		return fmt.Errorf("margin_bps must be between 0 and %d", MaxAnnualRateBps)
+	}
+	if req.InitialCapBps <= 0 {
+		return errors.New("initial_cap_bps must be > 0")
+	}
+	if req.PeriodicCapBps <= 0 {
+		return errors.New("periodic_cap_bps must be > 0")
+	}
+	if req.LifetimeCapBps <= 0 {
+		return errors.New("lifetime_cap_bps must be > 0")
+	}
+	if req.InitialRateBps+req.LifetimeCapBps > MaxAnnualRateBps {
+		return fmt.Errorf("initial_rate_bps + lifetime_cap_bps must be <= %d", MaxAnnualRateBps)
+	}
+	// A floor above the initial rate would force the first reset past
+	// initial_cap_bps; at or below it every move stays within its cap.
+	if req.FloorBps < 0 || req.FloorBps > req.InitialRateBps {
+		return errors.New("floor_bps must be >= 0 and <= initial_rate_bps")
+	}
+
+	if (len(req.IndexPathBps) > 0) == (len(req.RateChanges) > 0) {
+		return errors.New("exactly one of index_path_bps or rate_changes is required")
+	}
+	for i, v := range req.IndexPathBps {
+		if v < -MaxAnnualRateBps || v > MaxAnnualRateBps {
+			return fmt.Errorf("index_path_bps[%d] must be between %d and %d", i, -MaxAnnualRateBps, MaxAnnualRateBps)
+		}
+	}
+	seen := make(map[string]bool, len(req.RateChanges))
+	for i, c := range req.RateChanges {
+		if _, err := time.Parse("2006-01-02", c.Date); err != nil {
+			return fmt.Errorf("rate_changes[%d].date must be YYYY-MM-DD: %w", i, err)
+		}
+		if seen[c.Date] {
+			return fmt.Errorf("rate_changes[%d].date duplicates %s", i, c.Date)
+		}
+		seen[c.Date] = true
+		if c.IndexBps < -MaxAnnualRateBps || c.IndexBps > MaxAnnualRateBps {
+			return fmt.Errorf("rate_changes[%d].index_bps must be between %d and %d", i, -MaxAnnualRateBps, MaxAnnualRateBps)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package calc
+
+// ArmRequestV1 is the input contract for the v1 adjustable-rate mortgage
+// calculator.
+//
+// Money is integer cents and rates are basis points, as in
+// AmortizeRequestV1. Payments are monthly and interest accrues at
+// rate / 12 (30/360). Payment dates step whole months from StartDate,
+// clamped to the end of shorter months, and DateRoll takes the
+// AmortizeRequestV1 rules (none only skips the business-day adjustment).
+//
+// The loan pays InitialRateBps for the first InitialFixedMonths payments.
+// The rate then resets at that payment and every ResetFrequencyMonths
+// payments after it. Each reset takes the index (from exactly one of
+// IndexPathBps or RateChanges), adds MarginBps, and limits the move:
+// - by InitialCapBps at the first reset and PeriodicCapBps after it
+// - to at most InitialRateBps + LifetimeCapBps
+// - to at least FloorBps, which may not exceed InitialRateBps
+//
+// The payment is recast at every reset to amortize the remaining balance
+// over the remaining term.
+type ArmRequestV1 struct {
+	PrincipalCents int64  `json:"principal_cents"`
+	TermMonths     int    `json:"term_months"`
+	StartDate      string `json:"start_date"`
+	DateRoll       string `json:"date_roll,omitempty"`
+
+	InitialRateBps       int64 `json:"initial_rate_bps"`
+	InitialFixedMonths   int   `json:"initial_fixed_months"`
+	ResetFrequencyMonths int   `json:"reset_frequency_months"`
+	MarginBps            int64 `json:"margin_bps"`
+
+	InitialCapBps  int64 `json:"initial_cap_bps"`
+	PeriodicCapBps int64 `json:"periodic_cap_bps"`
+	LifetimeCapBps int64 `json:"lifetime_cap_bps"`
+	FloorBps       int64 `json:"floor_bps"`
+
+	// IndexPathBps lists the index at each reset in order; the last value
+	// carries forward if the loan has more resets than entries.
+	IndexPathBps []int64 `json:"index_path_bps,omitempty"`
+
+	// RateChanges lists dated index values; each reset uses the latest
+	// change dated on or before the reset's payment date.
+	RateChanges []IndexChangeV1 `json:"rate_changes,omitempty"`
+}
+
+// IndexChangeV1 is an index value effective from Date.
+type IndexChangeV1 struct {
+	Date     string `json:"date"`
+	IndexBps int64  `json:"index_bps"`
+}
+
+// ArmResponseV1 is the versioned JSON response for the v1 ARM calculator.
+//
+// Notes:
+// - initial_payment_cents is the payment during the initial fixed period
+// - resets lists every rate reset in schedule order
+// - max_rate_bps is the highest rate the schedule actually reached
+// - totals are deterministic and derived from the computed schedule
+type ArmResponseV1 struct {
+	SchemaVersion        string `json:"schema_version"`
+	Calculator           string `json:"calculator"`
+	PrincipalCents       int64  `json:"principal_cents"`
+	TermMonths           int    `json:"term_months"`
+	StartDate            string `json:"start_date"`
+	DateRoll             string `json:"date_roll,omitempty"`
+	InitialRateBps       int64  `json:"initial_rate_bps"`
+	InitialFixedMonths   int    `json:"initial_fixed_months"`
+	ResetFrequencyMonths int    `json:"reset_frequency_months"`
+	MarginBps            int64  `json:"margin_bps"`
+	InitialCapBps        int64  `json:"initial_cap_bps"`
+	PeriodicCapBps       int64  `json:"periodic_cap_bps"`
+	LifetimeCapBps       int64  `json:"lifetime_cap_bps"`
+	FloorBps             int64  `json:"floor_bps"`
+	InitialPaymentCents  int64  `json:"initial_payment_cents"`
+	LastPaymentCents     int64  `json:"last_payment_cents"`
+	MaxRateBps           int64  `json:"max_rate_bps"`
+	TotalInterestCents   int64  `json:"total_interest_cents"`
+	TotalPaidCents       int64  `json:"total_paid_cents"`
+
+	Resets []ArmResetV1 `json:"resets"`
+}
+
+// ArmResetV1 records one rate reset: the index used, the fully indexed rate
+// (index + margin), the capped/floored rate applied, and the recast payment.
+type ArmResetV1 struct {
+	Period          int    `json:"period"`
+	Date            string `json:"date"`
+	IndexBps        int64  `json:"index_bps"`
+	FullyIndexedBps int64  `json:"fully_indexed_bps"`
+	RateBps         int64  `json:"rate_bps"`
+	PaymentCents    int64  `json:"payment_cents"`
+}
+
+// ArmScheduleRow is one ARM schedule row. RateBps is the annual rate that
+// applied to the row's interest.
+type ArmScheduleRow struct {
+	Period         int
+	Date           string
+	RateBps        int64
+	PaymentCents   int64
+	PrincipalCents int64
+	InterestCents  int64
+	BalanceCents   int64
+}
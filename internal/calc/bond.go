@@ -0,0 +1,354 @@
+package calc
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+const (
+	calcNameBondPriceV1 = "bond_price"
+	calcNameBondYieldV1 = "bond_yield"
+)
+
+// Yield bounds for the bond calculators, in basis points.
+const (
+	MinBondYieldBps = int64(-9999)
+	MaxBondYieldBps = MaxAnnualRateBps
+)
+
+// couponFrequencies maps the accepted coupon frequencies to coupons per
+// year.
+var couponFrequencies = map[string]int64{
+	FrequencyAnnual:     1,
+	FrequencySemiAnnual: 2,
+	FrequencyQuarterly:  4,
+	FrequencyMonthly:    12,
+}
+
+// Bond pricing follows the US Treasury formula (31 CFR 356, Appendix B):
+// with i = yield / coupons per year and n coupons remaining,
+//
+//	dirty = sum_k CF_k / (1+i)^(k-1) / (1 + w*i)
+//
+// where w is the fraction of the current coupon period from settlement to
+// the next coupon. The fractional period is discounted at simple interest,
+// so every price is an exact rational and rounds to cents without
+// approximation.
+//
+// Accrual fractions by day_count, for settlement s in the period (p, q]:
+// - 30/360: days360(p, s) / (360 / f); w = days360(s, q) / (360 / f)
+// - actual/actual: ICMA (not the ISDA basis of AmortizeV1): days(p, s) / days(p, q); w = days(s, q) / days(p, q)
+// - actual/360, actual/365: actual days over 360 / f or 365 / f
+
+// BondPriceV1 prices a bond from yield_bps. The dirty price and accrued
+// interest are each rounded half-up to cents; the clean price is their
+// difference, so the three always tie out.
+func BondPriceV1(req BondPriceRequestV1) (BondResponseV1, []BondCashFlowRow, error) {
+	b, err := newBondPlan(req.BondTermsV1)
+	if err != nil {
+		return BondResponseV1{}, nil, err
+	}
+	if req.YieldBps < MinBondYieldBps || req.YieldBps > MaxBondYieldBps {
+		return BondResponseV1{}, nil, fmt.Errorf("yield_bps must be between %d and %d", MinBondYieldBps, MaxBondYieldBps)
+	}
+	i := new(big.Rat).SetFrac64(req.YieldBps, bpsDenom*b.perYear)
+	a := b.analytics(i)
+	dirty, err := roundRatHalfUpToInt64(a.price)
+	if err != nil {
+		return BondResponseV1{}, nil, err
+	}
+	return b.response(calcNameBondPriceV1, req.YieldBps, dirty-b.accruedCents, a)
+}
+
+// BondYieldV1 solves yield_bps from clean_price_cents. The target dirty
+// price is clean_price_cents plus the rounded accrued interest. The price
+// falls as the yield rises, so yield_bps is the largest k whose half-bp
+// boundary k - 1/2 still prices at or above the target: the exact yield
+// rounded half-up to a whole bp, decided by exact integer comparisons.
+// Duration and convexity are evaluated at yield_bps.
+func BondYieldV1(req BondYieldRequestV1) (BondResponseV1, []BondCashFlowRow, error) {
+	b, err := newBondPlan(req.BondTermsV1)
+	if err != nil {
+		return BondResponseV1{}, nil, err
+	}
+	if req.CleanPriceCents <= 0 {
+		return BondResponseV1{}, nil, errors.New("clean_price_cents must be > 0")
+	}
+	target, err := addInt64(req.CleanPriceCents, b.accruedCents)
+	if err != nil {
+		return BondResponseV1{}, nil, err
+	}
+	if !b.pricesAtLeast(MinBondYieldBps, target) {
+		return BondResponseV1{}, nil, fmt.Errorf("clean_price_cents is above the price at yield %d bps", MinBondYieldBps)
+	}
+	if b.pricesAtLeast(MaxBondYieldBps+1, target) {
+		return BondResponseV1{}, nil, fmt.Errorf("clean_price_cents is below the price at yield %d bps", MaxBondYieldBps)
+	}
+	// Largest k in [MinBondYieldBps, MaxBondYieldBps] with pricesAtLeast(k).
+	k, err := searchInt64(MinBondYieldBps, MaxBondYieldBps, func(k int64) (bool, error) {
+		return !b.pricesAtLeast(k+1, target), nil
+	})
+	if err != nil {
+		return BondResponseV1{}, nil, err
+	}
+	i := new(big.Rat).SetFrac64(k, bpsDenom*b.perYear)
+	return b.response(calcNameBondYieldV1, k, req.CleanPriceCents, b.analytics(i))
+}
+
+// bondPlan is a validated bond resolved into its remaining coupons.
+type bondPlan struct {
+	terms        BondTermsV1
+	frequency    string
+	dayCount     string
+	perYear      int64
+	prev, next   time.Time
+	coupons      []BondCashFlowRow
+	couponCents  int64
+	accruedDays  int64
+	accruedCents int64
+	w            *big.Rat // fraction of the period from settlement to next
+}
+
+func newBondPlan(t BondTermsV1) (bondPlan, error) {
+	if t.FaceCents <= 0 {
+		return bondPlan{}, errors.New("face_cents must be > 0")
+	}
+	if t.FaceCents > MaxPrincipalCents {
+		return bondPlan{}, fmt.Errorf("face_cents must be <= %d", MaxPrincipalCents)
+	}
+	if t.CouponRateBps < 0 || t.CouponRateBps > MaxAnnualRateBps {
+		return bondPlan{}, fmt.Errorf("coupon_rate_bps must be between 0 and %d", MaxAnnualRateBps)
+	}
+	freq := t.CouponFrequency
+	if freq == "" {
+		freq = FrequencySemiAnnual
+	}
+	perYear, ok := couponFrequencies[freq]
+	if !ok {
+		return bondPlan{}, errors.New("coupon_frequency must be one of annual, semi_annual, quarterly, monthly")
+	}
+	dc := t.DayCount
+	if dc == "" {
+		dc = DayCount30360
+	}
+	if !validDayCount(dc) {
+		return bondPlan{}, errors.New("day_count must be one of 30/360, actual/365, actual/360, actual/actual")
+	}
+	settle, err := time.Parse("2006-01-02", t.SettlementDate)
+	if err != nil {
+		return bondPlan{}, fmt.Errorf("settlement_date must be YYYY-MM-DD: %w", err)
+	}
+	maturity, err := time.Parse("2006-01-02", t.MaturityDate)
+	if err != nil {
+		return bondPlan{}, fmt.Errorf("maturity_date must be YYYY-MM-DD: %w", err)
+	}
+	settle, maturity = settle.UTC(), maturity.UTC()
+	if !settle.Before(maturity) {
+		return bondPlan{}, errors.New("settlement_date must be before maturity_date")
+	}
+	if settle.Before(addMonthsClamped(maturity, -MaxTermMonths)) {
+		return bondPlan{}, fmt.Errorf("maturity_date must be within %d months of settlement_date", MaxTermMonths)
+	}
+
+	annual, err := mulInt64(t.FaceCents, t.CouponRateBps)
+	if err != nil {
+		return bondPlan{}, err
+	}
+	coupon, err := roundDivHalfUp(annual, bpsDenom*perYear)
+	if err != nil {
+		return bondPlan{}, err
+	}
+
+	// Step back from maturity until a coupon date is on or before settlement.
+	step := int(monthsPerYr / perYear)
+	k := 1
+	for addMonthsEOM(maturity, -k*step).After(settle) {
+		k++
+	}
+	b := bondPlan{
+		terms:       t,
+		frequency:   freq,
+		dayCount:    dc,
+		perYear:     perYear,
+		prev:        addMonthsEOM(maturity, -k*step),
+		next:        addMonthsEOM(maturity, -(k-1)*step),
+		couponCents: coupon,
+	}
+	for j := 1; j <= k; j++ {
+		row := BondCashFlowRow{
+			Period:        j,
+			Date:          addMonthsEOM(maturity, -(k-j)*step).Format("2006-01-02"),
+			CouponCents:   coupon,
+			CashFlowCents: coupon,
+		}
+		if j == k {
+			row.PrincipalCents = t.FaceCents
+			if row.CashFlowCents, err = addInt64(coupon, t.FaceCents); err != nil {
+				return bondPlan{}, err
+			}
+		}
+		b.coupons = append(b.coupons, row)
+	}
+
+	accrued := b.periodFraction(b.prev, settle)
+	b.w = b.periodFraction(settle, b.next)
+	if dc == DayCount30360 {
+		b.accruedDays = days360(b.prev, settle)
+	} else {
+		b.accruedDays = daysBetween(b.prev, settle)
+	}
+	if b.accruedCents, err = roundRatHalfUpToInt64(accrued.Mul(accrued, new(big.Rat).SetInt64(coupon))); err != nil {
+		return bondPlan{}, err
+	}
+	return b, nil
+}
+
+// periodFraction is the share of the current coupon period from a to b
+// under the plan's day count.
+func (b bondPlan) periodFraction(from, to time.Time) *big.Rat {
+	switch b.dayCount {
+	case DayCount30360:
+		return big.NewRat(days360(from, to)*b.perYear, 360)
+	case DayCountActual360:
+		return big.NewRat(daysBetween(from, to)*b.perYear, 360)
+	case DayCountActual365:
+		return big.NewRat(daysBetween(from, to)*b.perYear, 365)
+	default:
+		return big.NewRat(daysBetween(from, to), daysBetween(b.prev, b.next))
+	}
+}
+
+// pricesAtLeast reports whether the dirty price at the half-bp boundary
+// k - 1/2 is at least target cents. With i = a/B (a = 2k-1,
+// B = 20000 * perYear), X = B + a and w = wn/wd, multiplying
+// sum CF_j * (B/X)^(j-1) >= target * (1 + w*i) through by B * wd * X^(n-1)
+// leaves integers only:
+//
+//	B * wd * sum CF_j * B^(j-1) * X^(n-j)  >=  target * (B*wd + wn*a) * X^(n-1)
+func (b bondPlan) pricesAtLeast(k int64, target int64) bool {
+	a := big.NewInt(2*k - 1)
+	bb := big.NewInt(2 * bpsDenom * b.perYear)
+	x := new(big.Int).Add(bb, a)
+	wn, wd := b.w.Num(), b.w.Denom()
+
+	s := new(big.Int)
+	bPow := big.NewInt(1)
+	for _, c := range b.coupons {
+		s.Mul(s, x)
+		s.Add(s, new(big.Int).Mul(big.NewInt(c.CashFlowCents), bPow))
+		bPow.Mul(bPow, bb)
+	}
+	lhs := s.Mul(s, bb)
+	lhs.Mul(lhs, wd)
+
+	rhs := new(big.Int).Mul(bb, wd)
+	rhs.Add(rhs, new(big.Int).Mul(wn, a))
+	rhs.Mul(rhs, big.NewInt(target))
+	rhs.Mul(rhs, new(big.Int).Exp(x, big.NewInt(int64(len(b.coupons)-1)), nil))
+	return lhs.Cmp(rhs) >= 0
+}
+
+// bondAnalytics holds the exact dirty price and its sensitivities at one
+// periodic yield i.
+type bondAnalytics struct {
+	price     *big.Rat
+	macaulay  *big.Rat
+	modified  *big.Rat
+	convexity *big.Rat
+}
+
+// analytics evaluates the dirty price P(i) = S(i) * u(i), u = 1/(1+w*i),
+// and its first two derivatives in i (P1, P2; likewise S1, S2) exactly:
+//
+//	P1 = S1*u - w*S*u^2
+//	P2 = S2*u - 2w*S1*u^2 + 2w^2*S*u^3
+//
+// With y = f*i the annual yield, modified duration is -P1/(f*P), convexity
+// is P2/(f^2*P) and Macaulay duration is modified * (1 + i).
+//
+// As in pricesAtLeast, the sums are taken over a common denominator with
+// integer Horner steps: with i = a/B, X = B + a and coupon t = 0..n-1,
+//
+//	S  =  sum CF_t * B^t * X^(n-1-t)                 / X^(n-1)
+//	S1 = -sum t * CF_t * B^t * X^(n-1-t) * B         / X^n
+//	S2 =  sum t(t+1) * CF_t * B^t * X^(n-1-t) * B^2  / X^(n+1)
+func (b bondPlan) analytics(i *big.Rat) bondAnalytics {
+	one := big.NewRat(1, 1)
+	onePlus := new(big.Rat).Add(one, i)
+
+	a, bb := i.Num(), i.Denom()
+	x := new(big.Int).Add(bb, a)
+	n0, n1, n2 := new(big.Int), new(big.Int), new(big.Int)
+	bPow := big.NewInt(1)
+	for j, c := range b.coupons {
+		t := int64(j) // j-1 for the 1-based coupon number j+1
+		term := new(big.Int).Mul(big.NewInt(c.CashFlowCents), bPow)
+		n0.Mul(n0, x).Add(n0, term)
+		n1.Mul(n1, x).Add(n1, new(big.Int).Mul(term, big.NewInt(t)))
+		n2.Mul(n2, x).Add(n2, new(big.Int).Mul(term, big.NewInt(t*(t+1))))
+		bPow.Mul(bPow, bb)
+	}
+	xPow := new(big.Int).Exp(x, big.NewInt(int64(len(b.coupons)-1)), nil)
+	s := new(big.Rat).SetFrac(n0, xPow)
+	xPow.Mul(xPow, x)
+	s1 := new(big.Rat).SetFrac(n1.Neg(n1.Mul(n1, bb)), xPow)
+	xPow.Mul(xPow, x)
+	s2 := new(big.Rat).SetFrac(n2.Mul(n2, new(big.Int).Mul(bb, bb)), xPow)
+
+	u := new(big.Rat).Inv(new(big.Rat).Add(one, new(big.Rat).Mul(b.w, i)))
+	u2 := new(big.Rat).Mul(u, u)
+	u3 := new(big.Rat).Mul(u2, u)
+	w := b.w
+	w2 := new(big.Rat).Mul(w, w)
+
+	price := new(big.Rat).Mul(s, u)
+
+	p1 := new(big.Rat).Mul(s1, u)
+	p1.Sub(p1, new(big.Rat).Mul(new(big.Rat).Mul(w, s), u2))
+
+	p2 := new(big.Rat).Mul(s2, u)
+	p2.Sub(p2, new(big.Rat).Mul(new(big.Rat).Mul(big.NewRat(2, 1), new(big.Rat).Mul(w, s1)), u2))
+	p2.Add(p2, new(big.Rat).Mul(new(big.Rat).Mul(big.NewRat(2, 1), new(big.Rat).Mul(w2, s)), u3))
+
+	f := new(big.Rat).SetInt64(b.perYear)
+	modified := new(big.Rat).Neg(p1)
+	modified.Quo(modified, new(big.Rat).Mul(f, price))
+	convexity := new(big.Rat).Quo(p2, new(big.Rat).Mul(new(big.Rat).Mul(f, f), price))
+	macaulay := new(big.Rat).Mul(modified, onePlus)
+	return bondAnalytics{price: price, macaulay: macaulay, modified: modified, convexity: convexity}
+}
+
+func (b bondPlan) response(name string, yieldBps, clean int64, a bondAnalytics) (BondResponseV1, []BondCashFlowRow, error) {
+	dirty, err := addInt64(clean, b.accruedCents)
+	if err != nil {
+		return BondResponseV1{}, nil, err
+	}
+	pct := new(big.Rat).SetFrac64(clean, b.terms.FaceCents)
+	pct.Mul(pct, big.NewRat(100, 1))
+	resp := BondResponseV1{
+		SchemaVersion:         schemaV1,
+		Calculator:            name,
+		FaceCents:             b.terms.FaceCents,
+		CouponRateBps:         b.terms.CouponRateBps,
+		CouponFrequency:       b.frequency,
+		DayCount:              b.dayCount,
+		SettlementDate:        b.terms.SettlementDate,
+		MaturityDate:          b.terms.MaturityDate,
+		PreviousCouponDate:    b.prev.Format("2006-01-02"),
+		NextCouponDate:        b.next.Format("2006-01-02"),
+		CouponsRemaining:      len(b.coupons),
+		CouponCents:           b.couponCents,
+		AccruedDays:           b.accruedDays,
+		YieldBps:              yieldBps,
+		CleanPriceCents:       clean,
+		AccruedInterestCents:  b.accruedCents,
+		DirtyPriceCents:       dirty,
+		CleanPricePct:         formatRatDecimal(pct, 6),
+		MacaulayDurationYears: formatRatDecimal(a.macaulay, 6),
+		ModifiedDurationYears: formatRatDecimal(a.modified, 6),
+		Convexity:             formatRatDecimal(a.convexity, 6),
+	}
+	return resp, b.coupons, nil
+}
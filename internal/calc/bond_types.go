@@ -0,0 +1,73 @@
+package calc
+
+// BondTermsV1 describes a fixed-rate bullet bond with regular coupons.
+//
+// Coupon dates step back from MaturityDate by whole coupon periods (a
+// month-end maturity keeps every coupon on a month end); there are no odd
+// first or last coupons. CouponFrequency is annual, semi_annual (the
+// default), quarterly or monthly. DayCount defaults to 30/360.
+type BondTermsV1 struct {
+	FaceCents       int64  `json:"face_cents"`
+	CouponRateBps   int64  `json:"coupon_rate_bps"`
+	CouponFrequency string `json:"coupon_frequency,omitempty"`
+	DayCount        string `json:"day_count,omitempty"`
+	SettlementDate  string `json:"settlement_date"`
+	MaturityDate    string `json:"maturity_date"`
+}
+
+// BondPriceRequestV1 prices a bond from its yield.
+type BondPriceRequestV1 struct {
+	BondTermsV1
+
+	YieldBps int64 `json:"yield_bps"`
+}
+
+// BondYieldRequestV1 solves a bond's yield from its clean price.
+type BondYieldRequestV1 struct {
+	BondTermsV1
+
+	CleanPriceCents int64 `json:"clean_price_cents"`
+}
+
+// BondResponseV1 is the versioned JSON response shared by the v1 bond
+// calculators.
+//
+// Notes:
+// - prices and accrued interest are cents on face_cents; clean_price_pct is the clean price per 100 of face
+// - dirty_price_cents = clean_price_cents + accrued_interest_cents
+// - durations are in years and convexity in years squared, as decimal strings rounded half-up to 6 places
+// - duration and convexity are evaluated at yield_bps
+type BondResponseV1 struct {
+	SchemaVersion      string `json:"schema_version"`
+	Calculator         string `json:"calculator"`
+	FaceCents          int64  `json:"face_cents"`
+	CouponRateBps      int64  `json:"coupon_rate_bps"`
+	CouponFrequency    string `json:"coupon_frequency"`
+	DayCount           string `json:"day_count"`
+	SettlementDate     string `json:"settlement_date"`
+	MaturityDate       string `json:"maturity_date"`
+	PreviousCouponDate string `json:"previous_coupon_date"`
+	NextCouponDate     string `json:"next_coupon_date"`
+	CouponsRemaining   int    `json:"coupons_remaining"`
+	CouponCents        int64  `json:"coupon_cents"`
+	AccruedDays        int64  `json:"accrued_days"`
+
+	YieldBps             int64  `json:"yield_bps"`
+	CleanPriceCents      int64  `json:"clean_price_cents"`
+	AccruedInterestCents int64  `json:"accrued_interest_cents"`
+	DirtyPriceCents      int64  `json:"dirty_price_cents"`
+	CleanPricePct        string `json:"clean_price_pct"`
+
+	MacaulayDurationYears string `json:"macaulay_duration_years"`
+	ModifiedDurationYears string `json:"modified_duration_years"`
+	Convexity             string `json:"convexity"`
+}
+
+// BondCashFlowRow is one remaining coupon date of the bond.
+type BondCashFlowRow struct {
+	Period         int
+	Date           string
+	CouponCents    int64
+	PrincipalCents int64
+	CashFlowCents  int64
+}
@@ -0,0 +1,520 @@
+package calc
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+)
+
+const (
+	calcNameNpvV1  = "npv"
+	calcNameIrrV1  = "irr"
+	calcNameXirrV1 = "xirr"
+)
+
+// Rate bounds for NPV inputs and IRR/XIRR results, in basis points. A rate
+// of -10000 bps (-100%) would discount by zero.
+const (
+	MinCashFlowRateBps = int64(-9999)
+	MaxCashFlowRateBps = int64(1_000_000)
+)
+
+// MaxCashFlows bounds the flows in one NPV, IRR or XIRR request: one per
+// month of the longest loan term plus the flow at time zero. The exact
+// root-finding cost grows faster than linearly in the number of flows.
+const MaxCashFlows = MaxTermMonths + 1
+
+// maxXirrSpanDays bounds the days between the first and last XIRR flow
+// (MaxTermMonths worth of years).
+const maxXirrSpanDays = MaxTermMonths / monthsPerYr * 366
+
+// irrMaxSteps bounds the bisection. A root still within the final bracket of
+// a half-bp boundary after this many halvings is treated as on it.
+const irrMaxSteps = 256
+
+// NpvV1 discounts periodic cash flows at rate_bps per period. The present
+// value is computed exactly and rounded once, half-up in magnitude.
+func NpvV1(req NpvRequestV1) (NpvResponseV1, error) {
+	if req.RateBps < MinCashFlowRateBps || req.RateBps > MaxCashFlowRateBps {
+		return NpvResponseV1{}, fmt.Errorf("rate_bps must be between %d and %d", MinCashFlowRateBps, MaxCashFlowRateBps)
+	}
+	in, out, err := cashFlowTotals("cash_flows_cents", req.CashFlowsCents)
+	if err != nil {
+		return NpvResponseV1{}, err
+	}
+	npv, err := npvCents(req.CashFlowsCents, req.RateBps)
+	if err != nil {
+		return NpvResponseV1{}, err
+	}
+	return NpvResponseV1{
+		SchemaVersion:    schemaV1,
+		Calculator:       calcNameNpvV1,
+		RateBps:          req.RateBps,
+		NumCashFlows:     len(req.CashFlowsCents),
+		NpvCents:         npv,
+		TotalInCents:     in,
+		TotalOutCents:    out,
+		NetCashFlowCents: in - out,
+	}, nil
+}
+
+// IrrV1 solves the per-period rate at which the cash flows' NPV is zero.
+// See solveCashFlowRate for the root-finding and rounding rules.
+func IrrV1(req IrrRequestV1) (IrrResponseV1, error) {
+	if _, _, err := cashFlowTotals("cash_flows_cents", req.CashFlowsCents); err != nil {
+		return IrrResponseV1{}, err
+	}
+	offsets := make([]int, len(req.CashFlowsCents))
+	for t := range offsets {
+		offsets[t] = t
+	}
+	irr, err := solveCashFlowRate("irr", newCashFlowPoly(offsets, req.CashFlowsCents), 1)
+	if err != nil {
+		return IrrResponseV1{}, err
+	}
+	npv, err := npvCents(req.CashFlowsCents, irr)
+	if err != nil {
+		return IrrResponseV1{}, err
+	}
+	return IrrResponseV1{
+		SchemaVersion: schemaV1,
+		Calculator:    calcNameIrrV1,
+		NumCashFlows:  len(req.CashFlowsCents),
+		IrrBps:        irr,
+		NpvAtIrrCents: npv,
+	}, nil
+}
+
+// XirrV1 solves the effective annual rate r at which
+// sum amount_j / (1+r)^(days_j/365) is zero, days_j counted from the
+// earliest date. See solveCashFlowRate for the rules.
+func XirrV1(req XirrRequestV1) (XirrResponseV1, error) {
+	// Bound the request before parsing its dates.
+	if err := cashFlowCount("cash_flows", len(req.CashFlows)); err != nil {
+		return XirrResponseV1{}, err
+	}
+	amounts := make([]int64, len(req.CashFlows))
+	dates := make([]time.Time, len(req.CashFlows))
+	for i, cf := range req.CashFlows {
+		d, err := time.Parse("2006-01-02", cf.Date)
+		if err != nil {
+			return XirrResponseV1{}, fmt.Errorf("cash_flows[%d].date must be YYYY-MM-DD: %w", i, err)
+		}
+		dates[i] = d.UTC()
+		amounts[i] = cf.AmountCents
+	}
+	if _, _, err := cashFlowTotals("cash_flows", amounts); err != nil {
+		return XirrResponseV1{}, err
+	}
+	first, last := dates[0], dates[0]
+	for _, d := range dates {
+		if d.Before(first) {
+			first = d
+		}
+		if d.After(last) {
+			last = d
+		}
+	}
+	if daysBetween(first, last) > maxXirrSpanDays {
+		return XirrResponseV1{}, fmt.Errorf("cash_flows must span at most %d days", maxXirrSpanDays)
+	}
+	offsets := make([]int, len(dates))
+	for i, d := range dates {
+		offsets[i] = int(daysBetween(first, d))
+	}
+	xirr, err := solveCashFlowRate("xirr", newCashFlowPoly(offsets, amounts), 365)
+	if err != nil {
+		return XirrResponseV1{}, err
+	}
+	return XirrResponseV1{
+		SchemaVersion: schemaV1,
+		Calculator:    calcNameXirrV1,
+		NumCashFlows:  len(req.CashFlows),
+		FirstDate:     first.Format("2006-01-02"),
+		LastDate:      last.Format("2006-01-02"),
+		XirrBps:       xirr,
+	}, nil
+}
+
+// cashFlowTotals validates a list of flows and returns the sums of the
+// inflows and (as a positive number) the outflows.
+func cashFlowTotals(field string, flows []int64) (in, out int64, err error) {
+	if err := cashFlowCount(field, len(flows)); err != nil {
+		return 0, 0, err
+	}
+	for i, c := range flows {
+		if c < -MaxPrincipalCents || c > MaxPrincipalCents {
+			return 0, 0, fmt.Errorf("%s[%d] must be between %d and %d", field, i, -MaxPrincipalCents, MaxPrincipalCents)
+		}
+		if c > 0 {
+			in, err = addInt64(in, c)
+		} else {
+			out, err = addInt64(out, -c)
+		}
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+	return in, out, nil
+}
+
+// cashFlowCount checks that a request has between 2 and MaxCashFlows flows.
+func cashFlowCount(field string, n int) error {
+	if n < 2 {
+		return fmt.Errorf("%s must have at least 2 flows", field)
+	}
+	if n > MaxCashFlows {
+		return fmt.Errorf("%s must have at most %d flows", field, MaxCashFlows)
+	}
+	return nil
+}
+
+// npvCents returns sum c_t / (1 + bps/10000)^t rounded half-up in
+// magnitude.
+func npvCents(flows []int64, bps int64) (int64, error) {
+	// Scale by (10000+bps)^(n-1): sum c_t * 10000^t * (10000+bps)^(n-1-t).
+	x := big.NewInt(bpsDenom + bps)
+	b := big.NewInt(bpsDenom)
+	s := new(big.Int)
+	bPow := big.NewInt(1)
+	for _, c := range flows {
+		s.Mul(s, x)
+		s.Add(s, new(big.Int).Mul(big.NewInt(c), bPow))
+		bPow.Mul(bPow, b)
+	}
+	v := new(big.Rat).SetFrac(s, new(big.Int).Exp(x, big.NewInt(int64(len(flows)-1)), nil))
+	sign := int64(1)
+	if v.Sign() < 0 {
+		sign = -1
+		v.Neg(v)
+	}
+	r, err := roundRatHalfUpToInt64(v)
+	if err != nil {
+		return 0, err
+	}
+	return sign * r, nil
+}
+
+// cashFlowPoly holds the non-zero net flows by time offset, offsets
+// shifted so the earliest is 0. coef[j] is the flow at offsets[j]; offsets
+// increase.
+type cashFlowPoly struct {
+	offsets []int
+	coef    []*big.Int
+}
+
+func newCashFlowPoly(offsets []int, amounts []int64) cashFlowPoly {
+	byOffset := make(map[int]int64, len(offsets))
+	for i, o := range offsets {
+		byOffset[o] += amounts[i] // bounded: each |amount| <= MaxPrincipalCents
+	}
+	keys := make([]int, 0, len(byOffset))
+	for o, c := range byOffset {
+		if c != 0 {
+			keys = append(keys, o)
+		}
+	}
+	sort.Ints(keys)
+	p := cashFlowPoly{offsets: make([]int, len(keys)), coef: make([]*big.Int, len(keys))}
+	for j, o := range keys {
+		p.offsets[j] = o - keys[0]
+		p.coef[j] = big.NewInt(byOffset[o])
+	}
+	return p
+}
+
+// signChanges counts sign changes between consecutive flows.
+func (p cashFlowPoly) signChanges() int {
+	n := 0
+	for j := 1; j < len(p.coef); j++ {
+		if p.coef[j].Sign() != p.coef[j-1].Sign() {
+			n++
+		}
+	}
+	return n
+}
+
+// term returns coef[j] * 2^(shift*offsets[j]).
+func (p cashFlowPoly) term(j int, shift uint) *big.Int {
+	return new(big.Int).Lsh(p.coef[j], shift*uint(p.offsets[j]))
+}
+
+// gapPowers returns num^g for g = offsets[j] - offsets[j-1], j >= 1,
+// computing each distinct gap once.
+func (p cashFlowPoly) gapPowers(num *big.Int) []*big.Int {
+	byGap := make(map[int]*big.Int)
+	pows := make([]*big.Int, len(p.offsets))
+	for j := 1; j < len(p.offsets); j++ {
+		g := p.offsets[j] - p.offsets[j-1]
+		if byGap[g] == nil {
+			byGap[g] = new(big.Int).Exp(num, big.NewInt(int64(g)), nil)
+		}
+		pows[j] = byGap[g]
+	}
+	return pows
+}
+
+// valueAt returns 2^(shift*D) * Q(w) at w = num/2^shift, where
+// Q(w) = sum coef[j] * w^(D-offsets[j]) and D is the last offset. Q(w) has
+// the sign of the NPV at the rate with (1+r)^(1/m) = w.
+func (p cashFlowPoly) valueAt(num *big.Int, shift uint) *big.Int {
+	return p.span(num, shift, 0, len(p.coef)-1)
+}
+
+// span returns sum coef[j] * num^(offsets[hi]-offsets[j]) *
+// 2^(shift*(offsets[j]-offsets[lo])) over lo <= j <= hi. Splitting the
+// flows in halves works over the flows only, not every offset, and keeps
+// the big multiplications balanced; a dense Horner over a 100-year XIRR
+// takes tens of thousands of steps at every bisection.
+func (p cashFlowPoly) span(num *big.Int, shift uint, lo, hi int) *big.Int {
+	if lo == hi {
+		return new(big.Int).Set(p.coef[lo])
+	}
+	mid := (lo + hi) / 2
+	left := p.span(num, shift, lo, mid)
+	left.Mul(left, new(big.Int).Exp(num, big.NewInt(int64(p.offsets[hi]-p.offsets[mid])), nil))
+	right := p.span(num, shift, mid+1, hi)
+	right.Lsh(right, shift*uint(p.offsets[mid+1]-p.offsets[lo]))
+	return left.Add(left, right)
+}
+
+func (p cashFlowPoly) signAt(num *big.Int, shift uint) int {
+	return p.valueAt(num, shift).Sign()
+}
+
+// rootBounds bounds the roots of Q on either side of w0 = num/2^shift > 0
+// by Descartes' rule applied to Q(w0*v) / (1 - v) and its reverse: the
+// roots in (w0, inf) number at most the sign changes of the partial sums of
+// a_j = coef[j] * w0^(D-offsets[j]) from the first flow (above), those in
+// (0, w0) at most the sign changes of the partial sums from the last flow
+// (below). The partial sums are the flows' running present values at w0,
+// forward and backward.
+func (p cashFlowPoly) rootBounds(num *big.Int, shift uint) (above, below int) {
+	total := p.valueAt(num, shift)
+	pows := p.gapPowers(num)
+	pw := big.NewInt(1) // num^(D-offsets[j])
+	back := new(big.Int)
+	fwdPrev, backPrev := total.Sign(), 0
+	for j := len(p.coef) - 1; j >= 0; j-- {
+		if j < len(p.coef)-1 {
+			pw.Mul(pw, pows[j+1])
+		}
+		back.Add(back, new(big.Int).Mul(p.term(j, shift), pw))
+		if s := back.Sign(); s != 0 {
+			if backPrev != 0 && s != backPrev {
+				below++
+			}
+			backPrev = s
+		}
+		if j > 0 {
+			// The forward sum through flow j-1 is total - back.
+			if s := new(big.Int).Sub(total, back).Sign(); s != 0 {
+				if fwdPrev != 0 && s != fwdPrev {
+					above++
+				}
+				fwdPrev = s
+			}
+		}
+	}
+	return above, below
+}
+
+// solveCashFlowRate finds the rate per m time units (per period for IRR with
+// m = 1, per 365 days for XIRR with m = 365) at which the NPV is zero,
+// rounded half-up to a whole basis point.
+//
+// Uniqueness: with w = (1+r)^(1/m) the NPV is a positive multiple of a
+// polynomial Q(w) whose coefficients are the flows in time order. By
+// Descartes' rule of signs exactly one sign change means exactly one root
+// w > 0, i.e. exactly one rate above -100%. With no sign change there is no
+// root. With more, the rate found must be the only one in the reportable
+// range (see rateIsUnique); otherwise the rate is ambiguous and rejected.
+//
+// Convergence: the root is bracketed by dyadic rationals and bisected with
+// exact integer sign tests. Each bracket maps to an exact rate interval
+// r = w^m - 1. Bisection stops as soon as no half-bp boundary lies inside
+// the interval, so the rounded result is exact. If irrMaxSteps halvings
+// still straddle a boundary, the root is taken to lie on it and rounds up.
+func solveCashFlowRate(name string, p cashFlowPoly, m int) (int64, error) {
+	n := p.signChanges()
+	if n == 0 {
+		return 0, errors.New("cash flows must include both positive and negative amounts")
+	}
+	ambiguous := fmt.Errorf("cash flows change sign %d times and have no unique %s", n, name)
+
+	// lo = 0 (rate -100%), hi = 2^k: Q's sign at lo is that of the last
+	// flow. With one sign change Q changes sign exactly once on (0, inf).
+	loNum, hiNum := big.NewInt(0), big.NewInt(1)
+	shift := uint(0)
+	loSign := p.coef[len(p.coef)-1].Sign()
+	maxRate := new(big.Rat).SetFrac64(MaxCashFlowRateBps, bpsDenom)
+	for p.signAt(hiNum, 0) == loSign {
+		if rateAt(hiNum, 0, m).Cmp(maxRate) > 0 {
+			if n > 1 {
+				return 0, ambiguous
+			}
+			return 0, fmt.Errorf("%s exceeds %d bps", name, MaxCashFlowRateBps)
+		}
+		loNum.Set(hiNum)
+		hiNum.Lsh(hiNum, 1)
+	}
+	// unique reports whether the root found in [lo, hi] is the only one
+	// in the reportable range.
+	unique := func(lo, hi *big.Int, shift uint) bool {
+		return n == 1 || rateIsUnique(p, m, lo, hi, shift)
+	}
+	if p.signAt(hiNum, 0) == 0 {
+		if !unique(hiNum, hiNum, 0) {
+			return 0, ambiguous
+		}
+		return roundRateBps(name, rateAt(hiNum, 0, m))
+	}
+
+	for step := 0; ; step++ {
+		rLo, rHi := rateAt(loNum, shift, m), rateAt(hiNum, shift, m)
+		kLo := floorRat(bpsHalfUp(rLo))
+		kHiCeil := ceilRat(bpsHalfUp(rHi))
+		if kLo.Cmp(new(big.Int).Sub(kHiCeil, big.NewInt(1))) == 0 {
+			if !unique(loNum, hiNum, shift) {
+				return 0, ambiguous
+			}
+			return boundRateBps(name, kLo)
+		}
+		if step == irrMaxSteps {
+			// The root is within 2^-irrMaxSteps of a boundary: round up.
+			if !unique(loNum, hiNum, shift) {
+				return 0, ambiguous
+			}
+			return boundRateBps(name, new(big.Int).Add(kLo, big.NewInt(1)))
+		}
+		if rHi.Cmp(new(big.Rat).SetFrac64(MinCashFlowRateBps*2-1, 2*bpsDenom)) < 0 {
+			return 0, fmt.Errorf("%s is below %d bps", name, MinCashFlowRateBps)
+		}
+
+		// mid = (lo + hi) / 2 at one more bit of precision.
+		shift++
+		loNum.Lsh(loNum, 1)
+		hiNum.Lsh(hiNum, 1)
+		mid := new(big.Int).Add(loNum, hiNum)
+		mid.Rsh(mid, 1)
+		switch s := p.signAt(mid, shift); {
+		case s == 0:
+			if !unique(mid, mid, shift) {
+				return 0, ambiguous
+			}
+			return roundRateBps(name, rateAt(mid, shift, m))
+		case s == loSign:
+			loNum = mid
+		default:
+			hiNum = mid
+		}
+	}
+}
+
+// rateIsUnique reports whether Q has no root in the reportable range
+// outside the bracket [lo, hi] (each num/2^shift; lo == hi for an exact
+// root). Every rate in the bracket rounds to the same bps, so that bps is
+// then the only result.
+//
+// Below the bracket, rootBounds at lo allowing no root in (0, lo), or at hi
+// allowing one root in (0, hi) (the bracketed one), settles it. Failing
+// that, the reportable range is widened to dyadic bounds wMin <=
+// w(-9999.5 bps) and wMax >= w(1000000.5 bps): if at most one root lies in
+// (0, lo) and Q has the same sign at wMin and lo, the count in (wMin, lo)
+// is even and so zero. Above the bracket, the same mirrored. The test is
+// sufficient, not necessary: it rejects only flows whose running present
+// values change sign more than once on one side at both ends of the
+// bracket.
+func rateIsUnique(p cashFlowPoly, m int, lo, hi *big.Int, shift uint) bool {
+	wMinNum, wMaxNum := reportableBounds(m)
+
+	// Compare lo and hi with the bounds at a common precision.
+	s := max(shift, reportableShift)
+	scale := func(num *big.Int, from uint) *big.Int { return new(big.Int).Lsh(num, s-from) }
+	loS, hiS := scale(lo, shift), scale(hi, shift)
+	wMin, wMax := scale(wMinNum, reportableShift), scale(wMaxNum, reportableShift)
+
+	exact := lo.Cmp(hi) == 0
+	aboveLo, belowLo := p.rootBounds(lo, shift)
+	aboveHi, belowHi := aboveLo, belowLo
+	if !exact {
+		aboveHi, belowHi = p.rootBounds(hi, shift)
+	}
+	if loS.Cmp(wMin) > 0 && belowLo != 0 && !(belowHi == 1 && !exact) {
+		same := p.signAt(wMinNum, reportableShift) == p.signAt(lo, shift)
+		if !(belowLo == 1 && same && !exact) {
+			return false
+		}
+	}
+	if hiS.Cmp(wMax) < 0 && aboveHi != 0 && !(aboveLo == 1 && !exact) {
+		same := p.signAt(wMaxNum, reportableShift) == p.signAt(hi, shift)
+		if !(aboveHi == 1 && same && !exact) {
+			return false
+		}
+	}
+	return true
+}
+
+// reportableShift is the precision of the reportable-range bounds.
+const reportableShift = 32
+
+// reportableBounds returns wMin and wMax (as num/2^reportableShift) with
+// wMin^m - 1 <= -9999.5 bps and wMax^m - 1 >= 1000000.5 bps.
+func reportableBounds(m int) (wMin, wMax *big.Int) {
+	rMin := new(big.Rat).SetFrac64(MinCashFlowRateBps*2-1, 2*bpsDenom)
+	rMax := new(big.Rat).SetFrac64(MaxCashFlowRateBps*2+1, 2*bpsDenom)
+	one := int64(1) << reportableShift
+	// w <= 1 + r, so wMax lies below (2 + rMax) * 2^reportableShift.
+	limit := (MaxCashFlowRateBps/bpsDenom + 2) * one
+	below := sort.Search(int(one), func(k int) bool {
+		return rateAt(big.NewInt(int64(k)), reportableShift, m).Cmp(rMin) > 0
+	})
+	above := sort.Search(int(limit), func(k int) bool {
+		return rateAt(big.NewInt(int64(k)), reportableShift, m).Cmp(rMax) >= 0
+	})
+	return big.NewInt(int64(below - 1)), big.NewInt(int64(above))
+}
+
+// rateAt returns w^m - 1 for w = num/2^shift.
+func rateAt(num *big.Int, shift uint, m int) *big.Rat {
+	w := new(big.Rat).SetFrac(num, new(big.Int).Lsh(big.NewInt(1), shift))
+	r := powRat(w, m)
+	return r.Sub(r, big.NewRat(1, 1))
+}
+
+// bpsHalfUp returns rate*10000 + 1/2, whose floor is the half-up rounded
+// rate in bps.
+func bpsHalfUp(rate *big.Rat) *big.Rat {
+	v := new(big.Rat).Mul(rate, big.NewRat(bpsDenom, 1))
+	return v.Add(v, big.NewRat(1, 2))
+}
+
+func roundRateBps(name string, rate *big.Rat) (int64, error) {
+	return boundRateBps(name, floorRat(bpsHalfUp(rate)))
+}
+
+func boundRateBps(name string, k *big.Int) (int64, error) {
+	if k.Cmp(big.NewInt(MaxCashFlowRateBps)) > 0 {
+		return 0, fmt.Errorf("%s exceeds %d bps", name, MaxCashFlowRateBps)
+	}
+	if k.Cmp(big.NewInt(MinCashFlowRateBps)) < 0 {
+		return 0, fmt.Errorf("%s is below %d bps", name, MinCashFlowRateBps)
+	}
+	return k.Int64(), nil
+}
+
+// floorRat floors r; big.Int.Div is Euclidean, which floors for the
+// positive denominator of a big.Rat.
+func floorRat(r *big.Rat) *big.Int {
+	return new(big.Int).Div(r.Num(), r.Denom())
+}
+
+func ceilRat(r *big.Rat) *big.Int {
+	q := floorRat(r)
+	if !r.IsInt() {
+		q.Add(q, big.NewInt(1))
+	}
+	return q
+}
@@ -0,0 +1,67 @@
+package calc
+
+// NpvRequestV1 is the input contract for the v1 NPV calculator.
+//
+// CashFlowsCents[t] is the (signed) cash flow at the end of period t; the
+// first flow is at t = 0 and is not discounted. RateBps is the discount rate
+// per period, in basis points.
+type NpvRequestV1 struct {
+	RateBps        int64   `json:"rate_bps"`
+	CashFlowsCents []int64 `json:"cash_flows_cents"`
+}
+
+// NpvResponseV1 is the versioned JSON response for the v1 NPV calculator.
+// npv_cents is the exact present value rounded half-up in magnitude.
+type NpvResponseV1 struct {
+	SchemaVersion    string `json:"schema_version"`
+	Calculator       string `json:"calculator"`
+	RateBps          int64  `json:"rate_bps"`
+	NumCashFlows     int    `json:"num_cash_flows"`
+	NpvCents         int64  `json:"npv_cents"`
+	TotalInCents     int64  `json:"total_in_cents"`
+	TotalOutCents    int64  `json:"total_out_cents"`
+	NetCashFlowCents int64  `json:"net_cash_flow_cents"`
+}
+
+// IrrRequestV1 is the input contract for the v1 IRR calculator: periodic
+// cash flows as in NpvRequestV1.
+type IrrRequestV1 struct {
+	CashFlowsCents []int64 `json:"cash_flows_cents"`
+}
+
+// IrrResponseV1 is the versioned JSON response for the v1 IRR calculator.
+// irr_bps is the per-period rate at which the NPV is zero, rounded half-up
+// to a whole basis point; npv_at_irr_cents is the NPV at irr_bps.
+type IrrResponseV1 struct {
+	SchemaVersion string `json:"schema_version"`
+	Calculator    string `json:"calculator"`
+	NumCashFlows  int    `json:"num_cash_flows"`
+	IrrBps        int64  `json:"irr_bps"`
+	NpvAtIrrCents int64  `json:"npv_at_irr_cents"`
+}
+
+// XirrRequestV1 is the input contract for the v1 XIRR calculator.
+//
+// Flows may be listed in any order; dates need not be unique. Time is
+// measured in actual days from the earliest date over 365.
+type XirrRequestV1 struct {
+	CashFlows []DatedCashFlowV1 `json:"cash_flows"`
+}
+
+// DatedCashFlowV1 is one dated, signed cash flow.
+type DatedCashFlowV1 struct {
+	Date        string `json:"date"`
+	AmountCents int64  `json:"amount_cents"`
+}
+
+// XirrResponseV1 is the versioned JSON response for the v1 XIRR calculator.
+// xirr_bps is the effective annual rate at which the dated flows discount to
+// zero, rounded half-up to a whole basis point.
+type XirrResponseV1 struct {
+	SchemaVersion string `json:"schema_version"`
+	Calculator    string `json:"calculator"`
+	NumCashFlows  int    `json:"num_cash_flows"`
+	FirstDate     string `json:"first_date"`
+	LastDate      string `json:"last_date"`
+	XirrBps       int64  `json:"xirr_bps"`
+}
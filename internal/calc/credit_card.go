@@ -0,0 +1,269 @@
+package calc
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+const calcNameCreditCardV1 = "credit_card"
+
+// Defaults for CreditCardRequestV1 fields left at zero.
+const (
+	DefaultMinimumPercentBps = int64(100)
+	DefaultMinimumFloorCents = int64(2500)
+	DefaultPaymentDueDays    = 25
+)
+
+// MaxPaymentDueDays keeps every payment inside its billing cycle: the
+// shortest cycle (February) has 28 days.
+const MaxPaymentDueDays = 27
+
+// creditCardThreeYearMonths is the payoff horizon of the CARD Act
+// disclosure payment.
+const creditCardThreeYearMonths = 36
+
+// CreditCardV1 projects the payoff of a revolving balance with no new
+// charges under three plans: minimum payments only, the optional fixed
+// payment, and the three-year payment.
+//
+// Statements close on StartDate plus whole months, clamped to month end.
+// Each cycle, the payment posts payment_due_days after the statement; the
+// cycle's interest is the sum of its daily balances times
+// annual_rate_bps / 365, computed exactly and rounded half-up. A payment
+// of at least the statement balance pays the account off: the final
+// payment is the balance plus the interest accrued to the payment date.
+//
+// The minimum payment on a statement is minimum_percent_bps of its
+// balance, rounded half-up, plus the interest it charged (statement_interest_cents on
+// the first statement), at least minimum_floor_cents. A plan that has not paid
+// off within MaxTermMonths cycles is an error, as is a fixed payment under
+// which the balance does not fall.
+func CreditCardV1(req CreditCardRequestV1) (CreditCardResponseV1, []CreditCardRow, error) {
+	if err := validateCreditCardReq(req); err != nil {
+		return CreditCardResponseV1{}, nil, err
+	}
+	start, _ := time.Parse("2006-01-02", req.StartDate)
+	start = start.UTC()
+	pct := req.MinimumPercentBps
+	if pct == 0 {
+		pct = DefaultMinimumPercentBps
+	}
+	floor := req.MinimumFloorCents
+	if floor == 0 {
+		floor = DefaultMinimumFloorCents
+	}
+	due := req.PaymentDueDays
+	if due == 0 {
+		due = DefaultPaymentDueDays
+	}
+	cc := creditCard{req: req, start: start, due: due}
+
+	minimum := func(bal, interest int64) (int64, error) {
+		p, err := mulInt64(bal, pct)
+		if err != nil {
+			return 0, err
+		}
+		if p, err = roundDivHalfUp(p, bpsDenom); err != nil {
+			return 0, err
+		}
+		if p, err = addInt64(p, interest); err != nil {
+			return 0, err
+		}
+		return max(p, floor), nil
+	}
+	minRows, paidOff, err := cc.plan(CreditCardPlanMinimum, minimum, MaxTermMonths, false)
+	if err != nil {
+		return CreditCardResponseV1{}, nil, err
+	}
+	if !paidOff {
+		return CreditCardResponseV1{}, nil, fmt.Errorf("minimum payments do not pay off the balance within %d months", MaxTermMonths)
+	}
+	rows := minRows
+
+	resp := CreditCardResponseV1{
+		SchemaVersion:          schemaV1,
+		Calculator:             calcNameCreditCardV1,
+		BalanceCents:           req.BalanceCents,
+		AnnualRateBps:          req.AnnualRateBps,
+		StartDate:              req.StartDate,
+		StatementInterestCents: req.StatementInterestCents,
+		MinimumPercentBps:      pct,
+		MinimumFloorCents:      floor,
+		PaymentDueDays:         due,
+	}
+	if resp.Minimum, err = creditCardSummary(CreditCardPlanMinimum, minRows, nil); err != nil {
+		return CreditCardResponseV1{}, nil, err
+	}
+
+	if req.FixedPaymentCents > 0 {
+		fixedRows, paidOff, err := cc.plan(CreditCardPlanFixed, fixedPayment(req.FixedPaymentCents), MaxTermMonths, true)
+		if err != nil {
+			return CreditCardResponseV1{}, nil, err
+		}
+		if !paidOff {
+			return CreditCardResponseV1{}, nil, errors.New("fixed_payment_cents does not pay off the balance: it does not cover the interest")
+		}
+		fixed, err := creditCardSummary(CreditCardPlanFixed, fixedRows, &resp.Minimum)
+		if err != nil {
+			return CreditCardResponseV1{}, nil, err
+		}
+		resp.Fixed = &fixed
+		rows = append(rows, fixedRows...)
+	}
+
+	// A payment of the whole balance pays off in the first cycle, so the
+	// search always succeeds.
+	payment, err := searchInt64(1, req.BalanceCents, func(p int64) (bool, error) {
+		_, paidOff, err := cc.plan(CreditCardPlanThreeYear, fixedPayment(p), creditCardThreeYearMonths, true)
+		return paidOff, err
+	})
+	if err != nil {
+		return CreditCardResponseV1{}, nil, err
+	}
+	threeRows, _, err := cc.plan(CreditCardPlanThreeYear, fixedPayment(payment), creditCardThreeYearMonths, true)
+	if err != nil {
+		return CreditCardResponseV1{}, nil, err
+	}
+	if resp.ThreeYear, err = creditCardSummary(CreditCardPlanThreeYear, threeRows, &resp.Minimum); err != nil {
+		return CreditCardResponseV1{}, nil, err
+	}
+	rows = append(rows, threeRows...)
+	return resp, rows, nil
+}
+
+// creditCard holds what every plan of one request shares.
+type creditCard struct {
+	req   CreditCardRequestV1
+	start time.Time
+	due   int
+}
+
+func fixedPayment(p int64) func(bal, interest int64) (int64, error) {
+	return func(int64, int64) (int64, error) { return p, nil }
+}
+
+// plan runs cycles until the balance is paid off or limit cycles have
+// run. pay gives the payment due on a statement balance and the interest
+// it charged. With stopIfStuck, a cycle that does not reduce the balance
+// ends the plan unpaid: the payment is fixed, so no later cycle would.
+func (cc creditCard) plan(name string, pay func(bal, interest int64) (int64, error), limit int, stopIfStuck bool) ([]CreditCardRow, bool, error) {
+	var rows []CreditCardRow
+	bal, interest := cc.req.BalanceCents, cc.req.StatementInterestCents
+	for i := 1; i <= limit; i++ {
+		from, to := addMonthsClamped(cc.start, i-1), addMonthsClamped(cc.start, i)
+		payDate := from.AddDate(0, 0, cc.due)
+		days := daysBetween(from, to)
+		row := CreditCardRow{
+			Plan:          name,
+			Cycle:         i,
+			PaymentDate:   payDate.Format("2006-01-02"),
+			StatementDate: to.Format("2006-01-02"),
+		}
+
+		p, err := pay(bal, interest)
+		if err != nil {
+			return nil, false, err
+		}
+		after, tail := bal-p, days-int64(cc.due)
+		if p >= bal {
+			after, tail = 0, 0
+		}
+		// Sum of daily balances: the statement balance up to the payment
+		// date, the balance after it for the rest of the cycle.
+		sum, err := mulInt64(bal, int64(cc.due))
+		if err != nil {
+			return nil, false, err
+		}
+		rest, err := mulInt64(after, tail)
+		if err != nil {
+			return nil, false, err
+		}
+		if sum, err = addInt64(sum, rest); err != nil {
+			return nil, false, err
+		}
+		if row.AverageDailyBalanceCents, err = roundDivHalfUp(sum, days); err != nil {
+			return nil, false, err
+		}
+		r := new(big.Rat).SetFrac64(cc.req.AnnualRateBps, bpsDenom*365)
+		if row.InterestCents, err = roundRatHalfUpToInt64(r.Mul(r, new(big.Rat).SetInt64(sum))); err != nil {
+			return nil, false, err
+		}
+
+		if p >= bal {
+			if row.PaymentCents, err = addInt64(bal, row.InterestCents); err != nil {
+				return nil, false, err
+			}
+			return append(rows, row), true, nil
+		}
+		row.PaymentCents = p
+		if row.BalanceCents, err = addInt64(after, row.InterestCents); err != nil {
+			return nil, false, err
+		}
+		rows = append(rows, row)
+		if stopIfStuck && row.BalanceCents >= bal {
+			return rows, false, nil
+		}
+		bal, interest = row.BalanceCents, row.InterestCents
+	}
+	return rows, false, nil
+}
+
+// creditCardSummary totals a paid-off plan. Savings are measured against
+// base, the minimum-payment plan, when given.
+func creditCardSummary(name string, rows []CreditCardRow, base *CreditCardPlanV1) (CreditCardPlanV1, error) {
+	s := CreditCardPlanV1{
+		Plan:              name,
+		FirstPaymentCents: rows[0].PaymentCents,
+		MonthsToPayoff:    len(rows),
+		PayoffDate:        rows[len(rows)-1].PaymentDate,
+	}
+	var err error
+	for _, r := range rows {
+		if s.TotalInterestCents, err = addInt64(s.TotalInterestCents, r.InterestCents); err != nil {
+			return CreditCardPlanV1{}, err
+		}
+		if s.TotalPaidCents, err = addInt64(s.TotalPaidCents, r.PaymentCents); err != nil {
+			return CreditCardPlanV1{}, err
+		}
+	}
+	if base != nil {
+		s.InterestSavingsCents = base.TotalInterestCents - s.TotalInterestCents
+	}
+	return s, nil
+}
+
+func validateCreditCardReq(req CreditCardRequestV1) error {
+	if req.BalanceCents <= 0 {
+		return errors.New("balance_cents must be > 0")
+	}
+	if req.BalanceCents > MaxPrincipalCents {
+		return fmt.Errorf("balance_cents must be <= %d", MaxPrincipalCents)
+	}
+	if req.AnnualRateBps < 0 {
+		return errors.New("annual_rate_bps must be >= 0")
+	}
+	if req.AnnualRateBps > MaxAnnualRateBps {
+		return fmt.Errorf("annual_rate_bps must be <= %d", MaxAnnualRateBps)
+	}
+	if _, err := time.Parse("2006-01-02", req.StartDate); err != nil {
+		return fmt.Errorf("start_date must be YYYY-MM-DD: %w", err)
+	}
+	if req.StatementInterestCents < 0 || req.StatementInterestCents > req.BalanceCents {
+		return errors.New("statement_interest_cents must be between 0 and balance_cents")
+	}
+	if req.MinimumPercentBps < 0 || req.MinimumPercentBps > bpsDenom {
+		return fmt.Errorf("minimum_percent_bps must be between 1 and %d (0 means %d)", bpsDenom, DefaultMinimumPercentBps)
+	}
+	if req.MinimumFloorCents < 0 || req.MinimumFloorCents > MaxPrincipalCents {
+		return fmt.Errorf("minimum_floor_cents must be between 1 and %d (0 means %d)", MaxPrincipalCents, DefaultMinimumFloorCents)
+	}
+	if req.PaymentDueDays < 0 || req.PaymentDueDays > MaxPaymentDueDays {
+		return fmt.Errorf("payment_due_days must be between 1 and %d (0 means %d)", MaxPaymentDueDays, DefaultPaymentDueDays)
+	}
+	if req.FixedPaymentCents < 0 || req.FixedPaymentCents > MaxPrincipalCents {
+		return fmt.Errorf("fixed_payment_cents must be between 0 and %d", MaxPrincipalCents)
+	}
+	return nil
+}
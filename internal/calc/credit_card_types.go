@@ -0,0 +1,89 @@
+package calc
+
+// Payment plans reported in CreditCardPlanV1.Plan and CreditCardRow.Plan.
+const (
+	CreditCardPlanMinimum   = "minimum"
+	CreditCardPlanFixed     = "fixed"
+	CreditCardPlanThreeYear = "three_year"
+)
+
+// CreditCardRequestV1 is the input contract for the v1 credit card
+// (revolving credit) payoff calculator.
+//
+// Money is integer cents and rates are basis points. BalanceCents is the
+// statement balance on StartDate; statements close monthly after it and no
+// new charges are made. Each payment posts PaymentDueDays (default 25)
+// days after a statement, and interest is charged when the next statement
+// closes on the cycle's average daily balance at AnnualRateBps / 365.
+//
+// The minimum payment is MinimumPercentBps (default 100, i.e. 1%) of the
+// statement balance plus the interest charged on that statement, at least
+// MinimumFloorCents (default 2500). StatementInterestCents is the interest
+// charged on the StartDate statement, which only the first minimum payment
+// uses. FixedPaymentCents, when set, adds a fixed-payment plan for
+// comparison.
+type CreditCardRequestV1 struct {
+	BalanceCents  int64  `json:"balance_cents"`
+	AnnualRateBps int64  `json:"annual_rate_bps"`
+	StartDate     string `json:"start_date"`
+
+	StatementInterestCents int64 `json:"statement_interest_cents,omitempty"`
+	MinimumPercentBps      int64 `json:"minimum_percent_bps,omitempty"`
+	MinimumFloorCents      int64 `json:"minimum_floor_cents,omitempty"`
+	PaymentDueDays         int   `json:"payment_due_days,omitempty"`
+	FixedPaymentCents      int64 `json:"fixed_payment_cents,omitempty"`
+}
+
+// CreditCardResponseV1 is the versioned JSON response for the v1 credit
+// card calculator.
+//
+// Notes:
+// - minimum pays only the minimum payment each month; fixed pays fixed_payment_cents (omitted when not requested)
+// - three_year is the smallest fixed payment that pays the balance off within 36 months, as in the CARD Act disclosure
+// - each plan's total_paid_cents = balance_cents + total_interest_cents; interest_savings_cents compares it with minimum
+type CreditCardResponseV1 struct {
+	SchemaVersion string `json:"schema_version"`
+	Calculator    string `json:"calculator"`
+
+	BalanceCents           int64  `json:"balance_cents"`
+	AnnualRateBps          int64  `json:"annual_rate_bps"`
+	StartDate              string `json:"start_date"`
+	StatementInterestCents int64  `json:"statement_interest_cents"`
+	MinimumPercentBps      int64  `json:"minimum_percent_bps"`
+	MinimumFloorCents      int64  `json:"minimum_floor_cents"`
+	PaymentDueDays         int    `json:"payment_due_days"`
+
+	Minimum   CreditCardPlanV1  `json:"minimum"`
+	Fixed     *CreditCardPlanV1 `json:"fixed,omitempty"`
+	ThreeYear CreditCardPlanV1  `json:"three_year"`
+}
+
+// CreditCardPlanV1 summarizes one payment plan. FirstPaymentCents is the
+// first month's payment; the last payment is the payoff amount.
+type CreditCardPlanV1 struct {
+	Plan                 string `json:"plan"`
+	FirstPaymentCents    int64  `json:"first_payment_cents"`
+	MonthsToPayoff       int    `json:"months_to_payoff"`
+	PayoffDate           string `json:"payoff_date"`
+	TotalInterestCents   int64  `json:"total_interest_cents"`
+	TotalPaidCents       int64  `json:"total_paid_cents"`
+	InterestSavingsCents int64  `json:"interest_savings_cents"`
+}
+
+// CreditCardRow is one billing cycle of a plan: the payment posted on
+// PaymentDate and the interest charged when the cycle's statement closes
+// on StatementDate. Each row ties out:
+// - previous balance - PaymentCents + InterestCents = BalanceCents
+//
+// In the final cycle the payment is the payoff amount: the balance plus
+// the interest accrued to PaymentDate, which is the row's InterestCents.
+type CreditCardRow struct {
+	Plan                     string
+	Cycle                    int
+	PaymentDate              string
+	StatementDate            string
+	PaymentCents             int64
+	AverageDailyBalanceCents int64
+	InterestCents            int64
+	BalanceCents             int64
+}
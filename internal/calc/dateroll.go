@@ -0,0 +1,151 @@
+package calc
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Date-roll rules accepted in AmortizeRequestV1.DateRoll.
+//
+// none (the default) keeps Go's time.AddDate month arithmetic, so a loan
+// starting 2026-01-31 has a row dated 2026-03-03. Every other rule steps
+// months without overflow, clamping to the last day of shorter months, and
+// then adjusts:
+// - eom: no business-day adjustment; a start_date on a month end keeps every month-stepped date on a month end
+// - following: move forward to the next business day
+// - modified_following: following, unless that crosses into the next month, then preceding
+// - preceding: move back to the previous business day
+//
+// Business days are Monday through Friday, excluding the holiday calendar.
+const (
+	DateRollNone              = "none"
+	DateRollEOM               = "eom"
+	DateRollFollowing         = "following"
+	DateRollModifiedFollowing = "modified_following"
+	DateRollPreceding         = "preceding"
+)
+
+func validDateRoll(rule string) bool {
+	switch rule {
+	case "", DateRollNone, DateRollEOM, DateRollFollowing, DateRollModifiedFollowing, DateRollPreceding:
+		return true
+	}
+	return false
+}
+
+// HolidayCalendar is a set of non-business dates. Weekends are always
+// non-business days and need not be listed. A nil calendar has no holidays.
+type HolidayCalendar struct {
+	days map[string]struct{}
+}
+
+// ParseHolidayCalendar reads one YYYY-MM-DD date per line. Blank lines and
+// lines starting with '#' are ignored; CRLF input is accepted. Duplicate or
+// malformed dates fail with the offending line number.
+func ParseHolidayCalendar(r io.Reader) (*HolidayCalendar, error) {
+	cal := &HolidayCalendar{days: make(map[string]struct{})}
+	sc := bufio.NewScanner(r)
+	line := 0
+	for sc.Scan() {
+		line++
+		s := strings.TrimSpace(sc.Text())
+		if s == "" || strings.HasPrefix(s, "#") {
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", s); err != nil {
+			return nil, fmt.Errorf("holiday calendar line %d: date must be YYYY-MM-DD", line)
+		}
+		if _, dup := cal.days[s]; dup {
+			return nil, fmt.Errorf("holiday calendar line %d: duplicate date %s", line, s)
+		}
+		cal.days[s] = struct{}{}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("holiday calendar: %w", err)
+	}
+	return cal, nil
+}
+
+// IsBusinessDay reports whether t is a weekday that is not a holiday.
+func (c *HolidayCalendar) IsBusinessDay(t time.Time) bool {
+	if wd := t.Weekday(); wd == time.Saturday || wd == time.Sunday {
+		return false
+	}
+	if c == nil {
+		return true
+	}
+	_, holiday := c.days[t.Format("2006-01-02")]
+	return !holiday
+}
+
+// rollDate applies a business-day rule to an unadjusted date.
+func rollDate(t time.Time, rule string, cal *HolidayCalendar) time.Time {
+	switch rule {
+	case DateRollFollowing:
+		return nextBusinessDay(t, 1, cal)
+	case DateRollPreceding:
+		return nextBusinessDay(t, -1, cal)
+	case DateRollModifiedFollowing:
+		f := nextBusinessDay(t, 1, cal)
+		if f.Month() != t.Month() {
+			return nextBusinessDay(t, -1, cal)
+		}
+		return f
+	}
+	return t
+}
+
+func nextBusinessDay(t time.Time, step int, cal *HolidayCalendar) time.Time {
+	for !cal.IsBusinessDay(t) {
+		t = t.AddDate(0, 0, step)
+	}
+	return t
+}
+
+// monthAdder advances a date by whole months.
+type monthAdder func(t time.Time, months int) time.Time
+
+// addMonthsGo uses time.AddDate, which overflows short months
+// (2026-01-31 + 1 month = 2026-03-03).
+func addMonthsGo(t time.Time, months int) time.Time {
+	return t.AddDate(0, months, 0)
+}
+
+// addMonthsClamped keeps the day of month, clamped to the target month's
+// last day (2026-01-31 + 1 month = 2026-02-28).
+func addMonthsClamped(t time.Time, months int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(months), 1, 0, 0, 0, 0, time.UTC)
+	d := t.Day()
+	if last := daysInMonth(first); d > last {
+		d = last
+	}
+	return time.Date(first.Year(), first.Month(), d, 0, 0, 0, 0, time.UTC)
+}
+
+// addMonthsEOM is addMonthsClamped, except a month-end date stays on the
+// month end (2026-02-28 + 1 month = 2026-03-31).
+func addMonthsEOM(t time.Time, months int) time.Time {
+	if t.Day() != daysInMonth(t) {
+		return addMonthsClamped(t, months)
+	}
+	first := time.Date(t.Year(), t.Month()+time.Month(months), 1, 0, 0, 0, 0, time.UTC)
+	return time.Date(first.Year(), first.Month(), daysInMonth(first), 0, 0, 0, 0, time.UTC)
+}
+
+// monthlyDueDate returns the date months after start under a date-roll
+// rule for calculators that step whole months. Unlike AmortizeV1's none,
+// months never overflow: none and the empty rule only skip the
+// business-day adjustment.
+func monthlyDueDate(start time.Time, months int, rule string, cal *HolidayCalendar) time.Time {
+	if rule == DateRollEOM {
+		return addMonthsEOM(start, months)
+	}
+	return rollDate(addMonthsClamped(start, months), rule, cal)
+}
+
+func daysInMonth(t time.Time) int {
+	return time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
@@ -0,0 +1,88 @@
+package calc
+
+import (
+	"math/big"
+	"time"
+)
+
+// Day-count conventions accepted in AmortizeRequestV1.DayCount.
+//
+// 30/360 treats every payment period as an equal share of a 360-day year,
+// i.e. the nominal periodic rate annual_rate / payments_per_year. It is the
+// default when day_count is empty.
+//
+// The actual conventions accrue on the calendar days between consecutive
+// schedule dates:
+// - actual/365: days / 365
+// - actual/360: days / 360
+// - actual/actual: ISDA; days falling in each calendar year divided by that year's length (365 or 366)
+const (
+	DayCount30360        = "30/360"
+	DayCountActual365    = "actual/365"
+	DayCountActual360    = "actual/360"
+	DayCountActualActual = "actual/actual"
+)
+
+func validDayCount(dc string) bool {
+	switch dc {
+	case "", DayCount30360, DayCountActual365, DayCountActual360, DayCountActualActual:
+		return true
+	}
+	return false
+}
+
+// accrualInterestCents returns interest on balanceCents for the accrual
+// period (from, to], rounded half-up to cents. perYear is the number of
+// payment periods per year, used by 30/360.
+func accrualInterestCents(balanceCents, annualRateBps, perYear int64, dayCount string, from, to time.Time) (int64, error) {
+	if dayCount == "" || dayCount == DayCount30360 {
+		return interestCents(balanceCents, annualRateBps, perYear)
+	}
+	if annualRateBps == 0 || balanceCents == 0 {
+		return 0, nil
+	}
+	// interest = round_half_up(balance * annual_bps / 10000 * year_fraction)
+	i := new(big.Rat).SetFrac(big.NewInt(balanceCents), big.NewInt(bpsDenom))
+	i.Mul(i, new(big.Rat).SetInt64(annualRateBps))
+	i.Mul(i, yearFraction(dayCount, from, to))
+	return roundRatHalfUpToInt64(i)
+}
+
+// yearFraction returns the exact year fraction of (from, to] under an actual
+// day-count convention.
+func yearFraction(dayCount string, from, to time.Time) *big.Rat {
+	switch dayCount {
+	case DayCountActual360:
+		return big.NewRat(daysBetween(from, to), 360)
+	case DayCountActualActual:
+		yf := new(big.Rat)
+		for y := from.Year(); y <= to.Year(); y++ {
+			lo := time.Date(y, time.January, 1, 0, 0, 0, 0, time.UTC)
+			hi := lo.AddDate(1, 0, 0)
+			if lo.Before(from) {
+				lo = from
+			}
+			if hi.After(to) {
+				hi = to
+			}
+			if d := daysBetween(lo, hi); d > 0 {
+				yf.Add(yf, big.NewRat(d, daysInYear(y)))
+			}
+		}
+		return yf
+	default:
+		return big.NewRat(daysBetween(from, to), 365)
+	}
+}
+
+// daysBetween counts calendar days from a to b. Both must be UTC dates.
+func daysBetween(a, b time.Time) int64 {
+	return int64(b.Sub(a).Hours()) / 24
+}
+
+func daysInYear(y int) int64 {
+	if y%4 == 0 && (y%100 != 0 || y%400 == 0) {
+		return 366
+	}
+	return 365
+}
@@ -0,0 +1,231 @@
+package calc
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+const calcNameDefermentV1 = "deferment"
+
+// DefermentV1 computes a student-loan schedule with deferment and
+// forbearance windows. Outside the windows it is a monthly AmortizeV1
+// schedule over term_months repayment months; the windows add:
+// - a month dated inside a window has no payment; its interest (none in a subsidized deferment) becomes unpaid interest
+// - unpaid interest is capitalized (added to principal) at a window's end, per its capitalize rule, and with the first month on or after each capitalization date
+// - a payment pays unpaid interest first, then principal
+// - after a window or a capitalization, the next payment is recast to amortize principal plus unpaid interest over the repayment months left
+//
+// Within a month interest accrues first, then the payment applies, then
+// any capitalization.
+func DefermentV1(req DefermentRequestV1) (DefermentResponseV1, []DefermentRow, error) {
+	start, wins, capDates, err := validateDefermentReq(req)
+	if err != nil {
+		return DefermentResponseV1{}, nil, err
+	}
+
+	pmt, err := scheduledPaymentCents(req.PrincipalCents, req.AnnualRateBps, req.TermMonths, monthsPerYr)
+	if err != nil {
+		return DefermentResponseV1{}, nil, err
+	}
+	resp := DefermentResponseV1{
+		SchemaVersion:       schemaV1,
+		Calculator:          calcNameDefermentV1,
+		PrincipalCents:      req.PrincipalCents,
+		AnnualRateBps:       req.AnnualRateBps,
+		TermMonths:          req.TermMonths,
+		StartDate:           req.StartDate,
+		InitialPaymentCents: pmt,
+		Windows:             make([]DefermentWindowSummaryV1, len(req.Windows)),
+		Recasts:             make([]DefermentRecastV1, 0),
+	}
+	for k, w := range req.Windows {
+		resp.Windows[k] = DefermentWindowSummaryV1{Kind: w.Kind, StartDate: w.StartDate, EndDate: w.EndDate}
+	}
+
+	rows := make([]DefermentRow, 0, req.TermMonths)
+	bal, unpaid := req.PrincipalCents, int64(0)
+	paid, nextCap, recast := 0, 0, false
+	for i := 1; paid < req.TermMonths && (bal > 0 || unpaid > 0); i++ {
+		if i > MaxTermMonths {
+			return DefermentResponseV1{}, nil, fmt.Errorf("windows extend the schedule beyond %d months", MaxTermMonths)
+		}
+		dt := addMonthsClamped(start, i-1)
+		w := defermentWindowAt(wins, dt)
+		row := DefermentRow{Period: i, Date: dt.Format("2006-01-02"), Status: "repayment"}
+
+		if w < 0 || !req.Windows[w].Subsidized {
+			if row.InterestCents, err = interestCents(bal, req.AnnualRateBps, monthsPerYr); err != nil {
+				return DefermentResponseV1{}, nil, err
+			}
+		}
+		due, err := addInt64(unpaid, row.InterestCents)
+		if err != nil {
+			return DefermentResponseV1{}, nil, err
+		}
+
+		if w >= 0 {
+			row.Status = req.Windows[w].Kind
+			resp.NonPaymentMonths++
+			resp.Windows[w].Months++
+			if resp.Windows[w].InterestAccruedCents, err = addInt64(resp.Windows[w].InterestAccruedCents, row.InterestCents); err != nil {
+				return DefermentResponseV1{}, nil, err
+			}
+			recast = true
+		} else {
+			if recast {
+				owed, err := addInt64(bal, unpaid)
+				if err != nil {
+					return DefermentResponseV1{}, nil, err
+				}
+				if pmt, err = scheduledPaymentCents(owed, req.AnnualRateBps, req.TermMonths-paid, monthsPerYr); err != nil {
+					return DefermentResponseV1{}, nil, err
+				}
+				resp.Recasts = append(resp.Recasts, DefermentRecastV1{
+					Period:         i,
+					Date:           row.Date,
+					AmortizedCents: owed,
+					PaymentsLeft:   req.TermMonths - paid,
+					PaymentCents:   pmt,
+				})
+				recast = false
+			}
+			paid++
+			row.InterestPaidCents = min(pmt, due)
+			row.PrincipalCents = pmt - row.InterestPaidCents
+			if row.PrincipalCents > bal || paid == req.TermMonths {
+				row.InterestPaidCents, row.PrincipalCents = due, bal
+			}
+			if row.PaymentCents, err = addInt64(row.InterestPaidCents, row.PrincipalCents); err != nil {
+				return DefermentResponseV1{}, nil, err
+			}
+			resp.LastPaymentCents = row.PaymentCents
+		}
+		unpaid = due - row.InterestPaidCents
+		bal -= row.PrincipalCents
+
+		capitalize := w >= 0 && req.Windows[w].Capitalize != CapitalizeNone && defermentWindowAt(wins, addMonthsClamped(start, i)) != w
+		for nextCap < len(capDates) && !capDates[nextCap].After(dt) {
+			capitalize = true
+			nextCap++
+		}
+		if capitalize && unpaid > 0 {
+			row.CapitalizedCents = unpaid
+			if bal, err = addInt64(bal, unpaid); err != nil {
+				return DefermentResponseV1{}, nil, err
+			}
+			if w >= 0 {
+				if resp.Windows[w].CapitalizedCents, err = addInt64(resp.Windows[w].CapitalizedCents, unpaid); err != nil {
+					return DefermentResponseV1{}, nil, err
+				}
+			}
+			if resp.TotalCapitalizedCents, err = addInt64(resp.TotalCapitalizedCents, unpaid); err != nil {
+				return DefermentResponseV1{}, nil, err
+			}
+			unpaid = 0
+			recast = true
+		}
+		row.UnpaidInterestCents = unpaid
+		row.BalanceCents = bal
+		rows = append(rows, row)
+	}
+
+	for k, s := range resp.Windows {
+		if s.Months == 0 {
+			return DefermentResponseV1{}, nil, fmt.Errorf("windows[%d] covers no month of the schedule", k)
+		}
+	}
+	if nextCap < len(capDates) {
+		return DefermentResponseV1{}, nil, errors.New("capitalization_dates must fall on or before the final payment date")
+	}
+
+	for _, r := range rows {
+		if resp.TotalInterestCents, err = addInt64(resp.TotalInterestCents, r.InterestCents); err != nil {
+			return DefermentResponseV1{}, nil, err
+		}
+		if resp.TotalPaidCents, err = addInt64(resp.TotalPaidCents, r.PaymentCents); err != nil {
+			return DefermentResponseV1{}, nil, err
+		}
+	}
+	resp.NumMonths = len(rows)
+	resp.MaturityDate = rows[len(rows)-1].Date
+	return resp, rows, nil
+}
+
+// defermentWindowAt returns the index of the window containing t, or -1.
+func defermentWindowAt(wins [][2]time.Time, t time.Time) int {
+	for k, w := range wins {
+		if !t.Before(w[0]) && !t.After(w[1]) {
+			return k
+		}
+	}
+	return -1
+}
+
+// validateDefermentReq checks the request and returns the parsed start
+// date, window bounds and sorted capitalization dates.
+func validateDefermentReq(req DefermentRequestV1) (time.Time, [][2]time.Time, []time.Time, error) {
+	var zero time.Time
+	if req.PrincipalCents <= 0 {
+		return zero, nil, nil, errors.New("principal_cents must be > 0")
+	}
+	if req.PrincipalCents > MaxPrincipalCents {
+		return zero, nil, nil, fmt.Errorf("principal_cents must be <= %d", MaxPrincipalCents)
+	}
+	if req.TermMonths <= 0 {
+		return zero, nil, nil, errors.New("term_months must be > 0")
+	}
+	if req.TermMonths > MaxTermMonths {
+		return zero, nil, nil, fmt.Errorf("term_months must be <= %d", MaxTermMonths)
+	}
+	if req.AnnualRateBps < 0 {
+		return zero, nil, nil, errors.New("annual_rate_bps must be >= 0")
+	}
+	if req.AnnualRateBps > MaxAnnualRateBps {
+		return zero, nil, nil, fmt.Errorf("annual_rate_bps must be <= %d", MaxAnnualRateBps)
+	}
+	start, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		return zero, nil, nil, fmt.Errorf("start_date must be YYYY-MM-DD: %w", err)
+	}
+
+	wins := make([][2]time.Time, len(req.Windows))
+	for k, w := range req.Windows {
+		if w.Kind != WindowDeferment && w.Kind != WindowForbearance {
+			return zero, nil, nil, fmt.Errorf("windows[%d].kind must be one of deferment, forbearance", k)
+		}
+		if w.Subsidized && w.Kind != WindowDeferment {
+			return zero, nil, nil, fmt.Errorf("windows[%d].subsidized applies to deferment only", k)
+		}
+		if w.Capitalize != "" && w.Capitalize != CapitalizeAtEnd && w.Capitalize != CapitalizeNone {
+			return zero, nil, nil, fmt.Errorf("windows[%d].capitalize must be one of at_end, none", k)
+		}
+		from, err := time.Parse("2006-01-02", w.StartDate)
+		if err != nil {
+			return zero, nil, nil, fmt.Errorf("windows[%d].start_date must be YYYY-MM-DD: %w", k, err)
+		}
+		to, err := time.Parse("2006-01-02", w.EndDate)
+		if err != nil {
+			return zero, nil, nil, fmt.Errorf("windows[%d].end_date must be YYYY-MM-DD: %w", k, err)
+		}
+		if from.Before(start) || to.Before(from) {
+			return zero, nil, nil, fmt.Errorf("windows[%d] must run from on or after start_date to an end_date on or after its start_date", k)
+		}
+		if k > 0 && !from.After(wins[k-1][1]) {
+			return zero, nil, nil, fmt.Errorf("windows[%d] must start after windows[%d] ends", k, k-1)
+		}
+		wins[k] = [2]time.Time{from, to}
+	}
+
+	capDates := make([]time.Time, len(req.CapitalizationDates))
+	for k, s := range req.CapitalizationDates {
+		d, err := time.Parse("2006-01-02", s)
+		if err != nil {
+			return zero, nil, nil, fmt.Errorf("capitalization_dates[%d] must be YYYY-MM-DD: %w", k, err)
+		}
+		capDates[k] = d
+	}
+	sort.Slice(capDates, func(a, b int) bool { return capDates[a].Before(capDates[b]) })
+	return start, wins, capDates, nil
+}
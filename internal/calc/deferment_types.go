@@ -0,0 +1,119 @@
+package calc
+
+// Non-payment window kinds accepted in DefermentWindowV1.Kind.
+const (
+	WindowDeferment   = "deferment"
+	WindowForbearance = "forbearance"
+)
+
+// Capitalization rules accepted in DefermentWindowV1.Capitalize.
+//
+// at_end (the default) adds the window's unpaid interest to principal with
+// the window's last month. none leaves it unpaid; later payments pay it
+// before any principal.
+const (
+	CapitalizeAtEnd = "at_end"
+	CapitalizeNone  = "none"
+)
+
+// DefermentRequestV1 is the input contract for the v1 deferment and
+// forbearance calculator (student loans).
+//
+// Money is integer cents and rates are basis points, as in
+// AmortizeRequestV1. Payments are monthly from StartDate and interest
+// accrues at rate / 12 (30/360) on principal only. TermMonths counts
+// repayment months: a month inside a window is added to the schedule, not
+// taken from the term.
+//
+// Windows are non-overlapping date ranges (inclusive) in which no payment
+// is due. Interest still accrues as unpaid interest, except in a subsidized
+// deferment. CapitalizationDates add all unpaid interest to principal with
+// the first month dated on or after each date. After a window or a
+// capitalization the payment is recast to amortize principal plus unpaid
+// interest over the remaining repayment months.
+type DefermentRequestV1 struct {
+	PrincipalCents int64  `json:"principal_cents"`
+	AnnualRateBps  int64  `json:"annual_rate_bps"`
+	TermMonths     int    `json:"term_months"`
+	StartDate      string `json:"start_date"`
+
+	Windows             []DefermentWindowV1 `json:"windows"`
+	CapitalizationDates []string            `json:"capitalization_dates,omitempty"`
+}
+
+// DefermentWindowV1 is one deferment or forbearance window. Subsidized
+// (deferment only) means no interest accrues in the window.
+type DefermentWindowV1 struct {
+	Kind       string `json:"kind"`
+	StartDate  string `json:"start_date"`
+	EndDate    string `json:"end_date"`
+	Subsidized bool   `json:"subsidized,omitempty"`
+	Capitalize string `json:"capitalize,omitempty"`
+}
+
+// DefermentResponseV1 is the versioned JSON response for the v1 deferment
+// calculator.
+//
+// Notes:
+// - initial_payment_cents amortizes principal_cents over term_months; recasts lists every later payment change
+// - total_interest_cents is all interest accrued: paid directly or capitalized and repaid as principal
+// - total_paid_cents = principal_cents + total_interest_cents
+type DefermentResponseV1 struct {
+	SchemaVersion string `json:"schema_version"`
+	Calculator    string `json:"calculator"`
+
+	PrincipalCents int64  `json:"principal_cents"`
+	AnnualRateBps  int64  `json:"annual_rate_bps"`
+	TermMonths     int    `json:"term_months"`
+	StartDate      string `json:"start_date"`
+
+	NumMonths             int    `json:"num_months"`
+	NonPaymentMonths      int    `json:"non_payment_months"`
+	MaturityDate          string `json:"maturity_date"`
+	InitialPaymentCents   int64  `json:"initial_payment_cents"`
+	LastPaymentCents      int64  `json:"last_payment_cents"`
+	TotalInterestCents    int64  `json:"total_interest_cents"`
+	TotalCapitalizedCents int64  `json:"total_capitalized_cents"`
+	TotalPaidCents        int64  `json:"total_paid_cents"`
+
+	Windows []DefermentWindowSummaryV1 `json:"windows"`
+	Recasts []DefermentRecastV1        `json:"recasts"`
+}
+
+// DefermentWindowSummaryV1 reports what happened in one window.
+type DefermentWindowSummaryV1 struct {
+	Kind                 string `json:"kind"`
+	StartDate            string `json:"start_date"`
+	EndDate              string `json:"end_date"`
+	Months               int    `json:"months"`
+	InterestAccruedCents int64  `json:"interest_accrued_cents"`
+	CapitalizedCents     int64  `json:"capitalized_cents"`
+}
+
+// DefermentRecastV1 records a payment recast: the amount it amortizes
+// (principal plus unpaid interest) and the repayment months left.
+type DefermentRecastV1 struct {
+	Period         int    `json:"period"`
+	Date           string `json:"date"`
+	AmortizedCents int64  `json:"amortized_cents"`
+	PaymentsLeft   int    `json:"payments_left"`
+	PaymentCents   int64  `json:"payment_cents"`
+}
+
+// DefermentRow is one month of the schedule. Status is repayment or the
+// window kind. Each row ties out:
+// - previous balance + CapitalizedCents - PrincipalCents = BalanceCents
+// - previous unpaid + InterestCents - InterestPaidCents - CapitalizedCents = UnpaidInterestCents
+// - PaymentCents = InterestPaidCents + PrincipalCents
+type DefermentRow struct {
+	Period              int
+	Date                string
+	Status              string
+	PaymentCents        int64
+	InterestCents       int64
+	InterestPaidCents   int64
+	PrincipalCents      int64
+	CapitalizedCents    int64
+	UnpaidInterestCents int64
+	BalanceCents        int64
+}
@@ -0,0 +1,226 @@
+package calc
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const calcNameDepreciationV1 = "depreciation"
+
+// MaxDepreciationLifeYears bounds life_years for the book methods.
+const MaxDepreciationLifeYears = 100
+
+// DefaultDecliningBalanceRatePct is the declining-balance rate when
+// declining_rate_pct is omitted: double-declining balance.
+const DefaultDecliningBalanceRatePct = int64(200)
+
+// DepreciationV1 computes a deterministic yearly depreciation schedule in
+// integer cents:
+// - straight_line: accumulated depreciation through year k is (cost - salvage) * k / life
+// - sum_of_years_digits: accumulated depreciation through year k is (cost - salvage) * (n + (n-1) + ... + (n-k+1)) / (n(n+1)/2)
+// - declining_balance: book value * rate / life, switching to straight-line over the remaining life once that is at least as large, never below salvage
+// - macrs: cost * the Publication 946 table percentage for each recovery year
+//
+// Every amount rounds half-up to cents. The straight-line and SYD methods
+// round the accumulated total rather than each year, and the last year of
+// every method takes exactly the remaining depreciable amount, so the final
+// book value always equals salvage_value_cents.
+func DepreciationV1(req DepreciationRequestV1) (DepreciationResponseV1, []DepreciationRow, error) {
+	start, err := validateDepreciationReq(req)
+	if err != nil {
+		return DepreciationResponseV1{}, nil, err
+	}
+
+	resp := DepreciationResponseV1{
+		SchemaVersion:       schemaV1,
+		Calculator:          calcNameDepreciationV1,
+		Method:              req.Method,
+		CostCents:           req.CostCents,
+		SalvageValueCents:   req.SalvageValueCents,
+		LifeYears:           req.LifeYears,
+		PlacedInServiceDate: req.PlacedInServiceDate,
+	}
+
+	depreciable := req.CostCents - req.SalvageValueCents
+	var deps []int64
+	switch req.Method {
+	case DepreciationStraightLine:
+		deps, err = cumulativeDepreciation(depreciable, req.LifeYears, func(k int) (int64, int64) {
+			return int64(k), int64(req.LifeYears)
+		})
+	case DepreciationSumOfYearsDigits:
+		n := int64(req.LifeYears)
+		deps, err = cumulativeDepreciation(depreciable, req.LifeYears, func(k int) (int64, int64) {
+			kk := int64(k)
+			return kk*n - kk*(kk-1)/2, n * (n + 1) / 2
+		})
+	case DepreciationDecliningBalance:
+		resp.DecliningRatePct = req.DecliningRatePct
+		if resp.DecliningRatePct == 0 {
+			resp.DecliningRatePct = DefaultDecliningBalanceRatePct
+		}
+		deps, resp.SwitchToStraightLinePeriod, err = decliningBalanceDepreciation(req.CostCents, req.SalvageValueCents, req.LifeYears, resp.DecliningRatePct)
+	case DepreciationMACRS:
+		resp.Convention = req.Convention
+		if resp.Convention == "" {
+			resp.Convention = MACRSConventionHalfYear
+		}
+		resp.MACRSTable = macrsTable(resp.Convention, (int(start.Month())+2)/3)
+		deps, err = macrsDepreciation(req.CostCents, macrsTables[resp.MACRSTable][req.LifeYears])
+	}
+	if err != nil {
+		return DepreciationResponseV1{}, nil, err
+	}
+
+	rows := make([]DepreciationRow, 0, len(deps))
+	var accumulated int64
+	for i, d := range deps {
+		accumulated += d
+		rows = append(rows, DepreciationRow{
+			Period:                       i + 1,
+			Year:                         start.Year() + i,
+			DepreciationCents:            d,
+			AccumulatedDepreciationCents: accumulated,
+			BookValueCents:               req.CostCents - accumulated,
+		})
+	}
+	resp.NumPeriods = len(rows)
+	resp.TotalDepreciationCents = accumulated
+	resp.FinalBookValueCents = req.CostCents - accumulated
+	return resp, rows, nil
+}
+
+// cumulativeDepreciation spreads depreciable over years by rounding the
+// accumulated total through each year k to depreciable * num/den, where
+// num/den = fraction(k) must reach 1 at k = years.
+func cumulativeDepreciation(depreciable int64, years int, fraction func(k int) (num, den int64)) ([]int64, error) {
+	deps := make([]int64, 0, years)
+	var prev int64
+	for k := 1; k <= years; k++ {
+		num, den := fraction(k)
+		n, err := mulInt64(depreciable, num)
+		if err != nil {
+			return nil, err
+		}
+		acc, err := roundDivHalfUp(n, den)
+		if err != nil {
+			return nil, err
+		}
+		deps = append(deps, acc-prev)
+		prev = acc
+	}
+	return deps, nil
+}
+
+// decliningBalanceDepreciation returns the yearly depreciation and the
+// first straight-line year (0 if the schedule never switches). Each year
+// takes the larger of book * ratePct / (100 * life) and the straight-line
+// amount (book - salvage) / remaining years, capped so the book value never
+// falls below salvage. In the last year the straight-line amount is the
+// whole remainder.
+func decliningBalanceDepreciation(cost, salvage int64, life int, ratePct int64) ([]int64, int, error) {
+	deps := make([]int64, 0, life)
+	book := cost
+	switchPeriod := 0
+	for k := 1; k <= life; k++ {
+		n, err := mulInt64(book, ratePct)
+		if err != nil {
+			return nil, 0, err
+		}
+		db, err := roundDivHalfUp(n, 100*int64(life))
+		if err != nil {
+			return nil, 0, err
+		}
+		sl, err := roundDivHalfUp(book-salvage, int64(life-k+1))
+		if err != nil {
+			return nil, 0, err
+		}
+		if switchPeriod == 0 && sl >= db && book > salvage {
+			switchPeriod = k
+		}
+		d := db
+		if switchPeriod != 0 {
+			d = sl
+		}
+		if d > book-salvage {
+			d = book - salvage
+		}
+		deps = append(deps, d)
+		book -= d
+	}
+	return deps, switchPeriod, nil
+}
+
+// macrsDepreciation applies a recovery table (in thousandths of a
+// percent) to cost. No year may take more than the remaining basis, and
+// the last recovery year takes all of it.
+func macrsDepreciation(cost int64, table []int64) ([]int64, error) {
+	deps := make([]int64, 0, len(table))
+	remaining := cost
+	for i, pct := range table {
+		n, err := mulInt64(cost, pct)
+		if err != nil {
+			return nil, err
+		}
+		d, err := roundDivHalfUp(n, macrsPctDenom)
+		if err != nil {
+			return nil, err
+		}
+		if d > remaining || i == len(table)-1 {
+			d = remaining
+		}
+		deps = append(deps, d)
+		remaining -= d
+	}
+	return deps, nil
+}
+
+func validateDepreciationReq(req DepreciationRequestV1) (time.Time, error) {
+	switch req.Method {
+	case DepreciationStraightLine, DepreciationDecliningBalance, DepreciationSumOfYearsDigits, DepreciationMACRS:
+	default:
+		return time.Time{}, errors.New("method must be one of straight_line, declining_balance, sum_of_years_digits, macrs")
+	}
+	if req.CostCents <= 0 {
+		return time.Time{}, errors.New("cost_cents must be > 0")
+	}
+	if req.CostCents > MaxPrincipalCents {
+		return time.Time{}, fmt.Errorf("cost_cents must be <= %d", MaxPrincipalCents)
+	}
+	if req.SalvageValueCents < 0 || req.SalvageValueCents > req.CostCents {
+		return time.Time{}, errors.New("salvage_value_cents must be between 0 and cost_cents")
+	}
+	start, err := time.Parse("2006-01-02", req.PlacedInServiceDate)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("placed_in_service_date must be YYYY-MM-DD: %w", err)
+	}
+	if req.DecliningRatePct != 0 {
+		if req.Method != DepreciationDecliningBalance {
+			return time.Time{}, errors.New("declining_rate_pct requires method declining_balance")
+		}
+		if req.DecliningRatePct < 100 || req.DecliningRatePct > 200 {
+			return time.Time{}, errors.New("declining_rate_pct must be between 100 and 200")
+		}
+	}
+	if req.Convention != "" && req.Method != DepreciationMACRS {
+		return time.Time{}, errors.New("convention requires method macrs")
+	}
+
+	if req.Method == DepreciationMACRS {
+		if req.Convention != "" && req.Convention != MACRSConventionHalfYear && req.Convention != MACRSConventionMidQuarter {
+			return time.Time{}, errors.New("convention must be one of half_year, mid_quarter")
+		}
+		if _, ok := macrsTables["A-1"][req.LifeYears]; !ok {
+			return time.Time{}, errors.New("life_years must be one of 3, 5, 7, 10, 15, 20 for macrs")
+		}
+		if req.SalvageValueCents != 0 {
+			return time.Time{}, errors.New("salvage_value_cents must be 0 for macrs")
+		}
+		return start, nil
+	}
+	if req.LifeYears <= 0 || req.LifeYears > MaxDepreciationLifeYears {
+		return time.Time{}, fmt.Errorf("life_years must be between 1 and %d", MaxDepreciationLifeYears)
+	}
+	return start, nil
+}
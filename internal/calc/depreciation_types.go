@@ -0,0 +1,79 @@
+package calc
+
+// Depreciation methods accepted in DepreciationRequestV1.Method.
+const (
+	DepreciationStraightLine     = "straight_line"
+	DepreciationDecliningBalance = "declining_balance"
+	DepreciationSumOfYearsDigits = "sum_of_years_digits"
+	DepreciationMACRS            = "macrs"
+)
+
+// MACRS conventions accepted in DepreciationRequestV1.Convention.
+const (
+	MACRSConventionHalfYear   = "half_year"
+	MACRSConventionMidQuarter = "mid_quarter"
+)
+
+// DepreciationRequestV1 is the input contract for the v1 depreciation
+// calculator.
+//
+// Money is integer cents. Periods are years; period 1 is the year that
+// contains PlacedInServiceDate.
+//
+// The book methods (straight_line, declining_balance, sum_of_years_digits)
+// depreciate CostCents down to SalvageValueCents over LifeYears full years.
+// DecliningRatePct sets the declining-balance rate as a percent of the
+// straight-line rate (200 for double-declining, the default, or 150); the
+// method switches to straight-line once that deducts at least as much.
+//
+// MACRS applies the IRS GDS percentage tables (Publication 946, Tables A-1
+// to A-5) to CostCents with no salvage value. LifeYears is the recovery
+// class (3, 5, 7, 10, 15 or 20) and Convention is half_year (the default)
+// or mid_quarter, where the quarter of PlacedInServiceDate picks the table.
+type DepreciationRequestV1 struct {
+	Method              string `json:"method"`
+	CostCents           int64  `json:"cost_cents"`
+	SalvageValueCents   int64  `json:"salvage_value_cents"`
+	LifeYears           int    `json:"life_years"`
+	PlacedInServiceDate string `json:"placed_in_service_date"`
+
+	DecliningRatePct int64  `json:"declining_rate_pct,omitempty"`
+	Convention       string `json:"convention,omitempty"`
+}
+
+// DepreciationResponseV1 is the versioned JSON response for the v1
+// depreciation calculator.
+//
+// Notes:
+// - total_depreciation_cents = cost_cents - salvage_value_cents exactly
+// - final_book_value_cents always equals salvage_value_cents
+// - macrs_table names the Publication 946 table applied (A-1 half-year, A-2 to A-5 mid-quarter)
+// - switch_to_straight_line_period is the first straight-line year of a declining-balance schedule (0 if it never switches)
+type DepreciationResponseV1 struct {
+	SchemaVersion       string `json:"schema_version"`
+	Calculator          string `json:"calculator"`
+	Method              string `json:"method"`
+	CostCents           int64  `json:"cost_cents"`
+	SalvageValueCents   int64  `json:"salvage_value_cents"`
+	LifeYears           int    `json:"life_years"`
+	PlacedInServiceDate string `json:"placed_in_service_date"`
+
+	DecliningRatePct           int64  `json:"declining_rate_pct,omitempty"`
+	SwitchToStraightLinePeriod int    `json:"switch_to_straight_line_period,omitempty"`
+	Convention                 string `json:"convention,omitempty"`
+	MACRSTable                 string `json:"macrs_table,omitempty"`
+
+	NumPeriods             int   `json:"num_periods"`
+	TotalDepreciationCents int64 `json:"total_depreciation_cents"`
+	FinalBookValueCents    int64 `json:"final_book_value_cents"`
+}
+
+// DepreciationRow is one year of a depreciation schedule. BookValueCents is
+// the value after this year's depreciation.
+type DepreciationRow struct {
+	Period                       int
+	Year                         int
+	DepreciationCents            int64
+	AccumulatedDepreciationCents int64
+	BookValueCents               int64
+}
@@ -0,0 +1,87 @@
+package calc
+
+import "time"
+
+// Payment frequencies accepted in AmortizeRequestV1.PaymentFrequency.
+// Empty means monthly.
+const (
+	FrequencyWeekly      = "weekly"
+	FrequencyBiweekly    = "biweekly"
+	FrequencySemiMonthly = "semi_monthly"
+	FrequencyMonthly     = "monthly"
+	FrequencyQuarterly   = "quarterly"
+	FrequencySemiAnnual  = "semi_annual"
+	FrequencyAnnual      = "annual"
+)
+
+// paymentFrequency describes how often payments fall due.
+//
+// Dates step by a whole number of days (weekly, biweekly) or months
+// (monthly and longer). Semi-monthly payments alternate between the start
+// day and 15 days later within each month.
+type paymentFrequency struct {
+	perYear int64
+	days    int
+	months  int
+}
+
+var frequencies = map[string]paymentFrequency{
+	FrequencyWeekly:      {perYear: 52, days: 7},
+	FrequencyBiweekly:    {perYear: 26, days: 14},
+	FrequencySemiMonthly: {perYear: 24},
+	FrequencyMonthly:     {perYear: 12, months: 1},
+	FrequencyQuarterly:   {perYear: 4, months: 3},
+	FrequencySemiAnnual:  {perYear: 2, months: 6},
+	FrequencyAnnual:      {perYear: 1, months: 12},
+}
+
+func lookupFrequency(name string) (paymentFrequency, bool) {
+	if name == "" {
+		name = FrequencyMonthly
+	}
+	f, ok := frequencies[name]
+	return f, ok
+}
+
+// payments returns the number of payments in termMonths. ok is false when
+// termMonths is not a whole number of payment periods (e.g. weekly terms
+// must be a multiple of 3 months so that 52 * months / 12 is whole).
+func (f paymentFrequency) payments(termMonths int) (n int, ok bool) {
+	total := int64(termMonths) * f.perYear
+	if total%monthsPerYr != 0 {
+		return 0, false
+	}
+	return int(total / monthsPerYr), true
+}
+
+// termMultiple is the smallest term (in months) that holds a whole number of
+// payment periods.
+func (f paymentFrequency) termMultiple() int {
+	m := 1
+	for {
+		if _, ok := f.payments(m); ok {
+			return m
+		}
+		m++
+	}
+}
+
+// dueDate returns the unadjusted date of payment i (1-based), stepping whole
+// months with addMonths. Payment 0 is one period before start and opens the
+// first accrual period.
+func (f paymentFrequency) dueDate(start time.Time, i int, addMonths monthAdder) time.Time {
+	k := i - 1
+	switch {
+	case f.days > 0:
+		return start.AddDate(0, 0, k*f.days)
+	case f.months > 0:
+		return addMonths(start, k*f.months)
+	default:
+		// semi-monthly: floor(k/2) whole months, plus 15 days on odd steps
+		m := k / 2
+		if k < 0 && k%2 != 0 {
+			m--
+		}
+		return addMonths(start, m).AddDate(0, 0, 15*(k-2*m))
+	}
+}
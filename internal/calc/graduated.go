@@ -0,0 +1,224 @@
+package calc
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+const calcNameGraduatedV1 = "graduated"
+
+// GraduatedV1 computes a graduated-payment schedule: a monthly AmortizeV1
+// schedule whose payment rises in steps instead of staying level.
+//
+// Step k's payment is the initial payment times the product of the first k
+// increases, computed exactly and rounded half-up once, so rounding never
+// compounds. Every step payment is monotone in the initial payment, and so
+// is the balance at each row; the initial payment is therefore a binary
+// search over cents for the smallest one whose schedule pays the loan off
+// within term_months. A payment below the month's interest amortizes
+// negatively: the principal column goes negative and the shortfall is added
+// to the balance.
+func GraduatedV1(req GraduatedRequestV1) (GraduatedResponseV1, []GraduatedRow, error) {
+	if err := validateGraduatedReq(req); err != nil {
+		return GraduatedResponseV1{}, nil, err
+	}
+	start, _ := time.Parse("2006-01-02", req.StartDate)
+	start = start.UTC()
+	steps := graduatedSteps(req)
+
+	// The level payment, plus a cent a month for its rounding residue, pays
+	// the loan off before any step, so it bounds the search.
+	level, err := scheduledPaymentCents(req.PrincipalCents, req.AnnualRateBps, req.TermMonths, monthsPerYr)
+	if err != nil {
+		return GraduatedResponseV1{}, nil, err
+	}
+	initial, err := searchInt64(0, level+int64(req.TermMonths), func(p int64) (bool, error) {
+		_, ok, err := graduatedRows(req, start, steps, p)
+		return ok, err
+	})
+	if err != nil {
+		return GraduatedResponseV1{}, nil, err
+	}
+	rows, ok, err := graduatedRows(req, start, steps, initial)
+	if err != nil {
+		return GraduatedResponseV1{}, nil, err
+	}
+	if !ok {
+		return GraduatedResponseV1{}, nil, errors.New("no initial payment up to the level payment pays the loan off")
+	}
+
+	resp := GraduatedResponseV1{
+		SchemaVersion:       schemaV1,
+		Calculator:          calcNameGraduatedV1,
+		PrincipalCents:      req.PrincipalCents,
+		AnnualRateBps:       req.AnnualRateBps,
+		TermMonths:          req.TermMonths,
+		StartDate:           req.StartDate,
+		InitialPaymentCents: initial,
+		LevelPaymentCents:   level,
+		LastPaymentCents:    rows[len(rows)-1].PaymentCents,
+		MaxBalanceCents:     req.PrincipalCents,
+	}
+	payments, err := graduatedPayments(steps, initial)
+	if err != nil {
+		return GraduatedResponseV1{}, nil, err
+	}
+	for k, s := range steps {
+		resp.Steps = append(resp.Steps, GraduatedStepV1{
+			Step:         k,
+			Period:       s.Period,
+			Date:         addMonthsClamped(start, s.Period-1).Format("2006-01-02"),
+			PaymentCents: payments[k],
+		})
+	}
+	for _, r := range rows {
+		if resp.TotalInterestCents, err = addInt64(resp.TotalInterestCents, r.InterestCents); err != nil {
+			return GraduatedResponseV1{}, nil, err
+		}
+		if resp.TotalPaidCents, err = addInt64(resp.TotalPaidCents, r.PaymentCents); err != nil {
+			return GraduatedResponseV1{}, nil, err
+		}
+		if r.NegativeAmortization {
+			resp.NegativeAmortization = true
+			resp.NegativeAmortizationPeriods++
+			if resp.FirstNegativeAmortizationPeriod == 0 {
+				resp.FirstNegativeAmortizationPeriod = r.Period
+			}
+			resp.LastNegativeAmortizationPeriod = r.Period
+			resp.DeferredInterestCents -= r.PrincipalCents
+		}
+		if r.BalanceCents > resp.MaxBalanceCents {
+			resp.MaxBalanceCents = r.BalanceCents
+			resp.MaxBalancePeriod = r.Period
+		}
+	}
+	return resp, rows, nil
+}
+
+// graduatedSteps returns the request's steps as a list starting with step
+// 0 (period 1, no increase).
+func graduatedSteps(req GraduatedRequestV1) []PaymentStepV1 {
+	steps := []PaymentStepV1{{Period: 1}}
+	if len(req.Steps) > 0 {
+		return append(steps, req.Steps...)
+	}
+	for k := 1; k <= req.NumSteps; k++ {
+		steps = append(steps, PaymentStepV1{Period: 1 + k*req.StepEveryMonths, IncreaseBps: req.StepIncreaseBps})
+	}
+	return steps
+}
+
+// graduatedPayments returns each step's payment for the given initial
+// payment: initial * prod(1 + increase/10000), rounded half-up once.
+func graduatedPayments(steps []PaymentStepV1, initial int64) ([]int64, error) {
+	payments := make([]int64, len(steps))
+	m := big.NewRat(1, 1)
+	for k, s := range steps {
+		m.Mul(m, big.NewRat(bpsDenom+s.IncreaseBps, bpsDenom))
+		p, err := roundRatHalfUpToInt64(new(big.Rat).Mul(m, new(big.Rat).SetInt64(initial)))
+		if err != nil {
+			return nil, err
+		}
+		payments[k] = p
+	}
+	return payments, nil
+}
+
+// graduatedRows walks the schedule for an initial payment. ok reports
+// whether the loan pays off within the term: some row's balance plus
+// interest is no more than its step payment. Otherwise (or if the balance
+// outgrows int64 cents) the rows are incomplete and ok is false.
+func graduatedRows(req GraduatedRequestV1, start time.Time, steps []PaymentStepV1, initial int64) ([]GraduatedRow, bool, error) {
+	payments, err := graduatedPayments(steps, initial)
+	if err != nil {
+		return nil, false, err
+	}
+	rows := make([]GraduatedRow, 0, req.TermMonths)
+	bal, k := req.PrincipalCents, 0
+	for i := 1; i <= req.TermMonths; i++ {
+		for k+1 < len(steps) && steps[k+1].Period <= i {
+			k++
+		}
+		interest, err := interestCents(bal, req.AnnualRateBps, monthsPerYr)
+		if errors.Is(err, errOverflow) {
+			return nil, false, nil
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		owed, err := addInt64(bal, interest)
+		if errors.Is(err, errOverflow) {
+			return nil, false, nil
+		}
+		if err != nil {
+			return nil, false, err
+		}
+
+		row := GraduatedRow{Period: i, Date: addMonthsClamped(start, i-1).Format("2006-01-02"), Step: k, InterestCents: interest}
+		if owed <= payments[k] {
+			row.PaymentCents, row.PrincipalCents = owed, bal
+			return append(rows, row), true, nil
+		}
+		row.PaymentCents = payments[k]
+		row.PrincipalCents = payments[k] - interest
+		row.NegativeAmortization = row.PrincipalCents < 0
+		bal -= row.PrincipalCents
+		row.BalanceCents = bal
+		rows = append(rows, row)
+	}
+	return rows, false, nil
+}
+
+func validateGraduatedReq(req GraduatedRequestV1) error {
+	if req.PrincipalCents <= 0 {
+		return errors.New("principal_cents must be > 0")
+	}
+	if req.PrincipalCents > MaxPrincipalCents {
+		return fmt.Errorf("principal_cents must be <= %d", MaxPrincipalCents)
+	}
+	if req.TermMonths <= 0 {
+		return errors.New("term_months must be > 0")
+	}
+	if req.TermMonths > MaxTermMonths {
+		return fmt.Errorf("term_months must be <= %d", MaxTermMonths)
+	}
+	if req.AnnualRateBps < 0 {
+		return errors.New("annual_rate_bps must be >= 0")
+	}
+	if req.AnnualRateBps > MaxAnnualRateBps {
+		return fmt.Errorf("annual_rate_bps must be <= %d", MaxAnnualRateBps)
+	}
+	if _, err := time.Parse("2006-01-02", req.StartDate); err != nil {
+		return fmt.Errorf("start_date must be YYYY-MM-DD: %w", err)
+	}
+
+	regular := req.StepIncreaseBps != 0 || req.StepEveryMonths != 0 || req.NumSteps != 0
+	if regular == (len(req.Steps) > 0) {
+		return errors.New("exactly one of step_increase_bps/step_every_months/num_steps or steps is required")
+	}
+	if regular {
+		if req.StepIncreaseBps <= 0 || req.StepIncreaseBps > bpsDenom {
+			return fmt.Errorf("step_increase_bps must be between 1 and %d", bpsDenom)
+		}
+		if req.StepEveryMonths <= 0 || req.NumSteps <= 0 {
+			return errors.New("step_every_months and num_steps must be > 0")
+		}
+		if req.NumSteps > (req.TermMonths-1)/req.StepEveryMonths {
+			return errors.New("every step must start within term_months (num_steps * step_every_months < term_months)")
+		}
+		return nil
+	}
+	last := 1
+	for i, s := range req.Steps {
+		if s.Period <= last || s.Period > req.TermMonths {
+			return fmt.Errorf("steps[%d].period must be after the previous step and <= term_months", i)
+		}
+		if s.IncreaseBps <= 0 || s.IncreaseBps > bpsDenom {
+			return fmt.Errorf("steps[%d].increase_bps must be between 1 and %d", i, bpsDenom)
+		}
+		last = s.Period
+	}
+	return nil
+}
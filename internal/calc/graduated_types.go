@@ -0,0 +1,87 @@
+package calc
+
+// GraduatedRequestV1 is the input contract for the v1 graduated (step)
+// payment calculator.
+//
+// Money is integer cents and rates are basis points, as in
+// AmortizeRequestV1. Payments are monthly from StartDate and interest
+// accrues at rate / 12 (30/360).
+//
+// The payment rises in steps, given by exactly one of:
+// - StepIncreaseBps, StepEveryMonths and NumSteps: the payment rises by StepIncreaseBps every StepEveryMonths payments, NumSteps times, then stays level
+// - Steps: each entry raises the payment by IncreaseBps from payment Period on
+//
+// The calculator solves the initial payment.
+type GraduatedRequestV1 struct {
+	PrincipalCents int64  `json:"principal_cents"`
+	AnnualRateBps  int64  `json:"annual_rate_bps"`
+	TermMonths     int    `json:"term_months"`
+	StartDate      string `json:"start_date"`
+
+	StepIncreaseBps int64 `json:"step_increase_bps,omitempty"`
+	StepEveryMonths int   `json:"step_every_months,omitempty"`
+	NumSteps        int   `json:"num_steps,omitempty"`
+
+	Steps []PaymentStepV1 `json:"steps,omitempty"`
+}
+
+// PaymentStepV1 raises the payment by IncreaseBps (of the previous
+// payment) starting with payment Period.
+type PaymentStepV1 struct {
+	Period      int   `json:"period"`
+	IncreaseBps int64 `json:"increase_bps"`
+}
+
+// GraduatedResponseV1 is the versioned JSON response for the v1 graduated
+// payment calculator.
+//
+// Notes:
+// - initial_payment_cents is the smallest whole-cent initial payment whose steps pay the loan off within term_months
+// - steps lists the scheduled payment of every step, starting with step 0 at period 1
+// - last_payment_cents is the final row, which pays the remaining balance (at most its step payment)
+// - negative amortization periods are payments below the month's interest; the shortfall is added to the balance as deferred interest
+type GraduatedResponseV1 struct {
+	SchemaVersion string `json:"schema_version"`
+	Calculator    string `json:"calculator"`
+
+	PrincipalCents int64  `json:"principal_cents"`
+	AnnualRateBps  int64  `json:"annual_rate_bps"`
+	TermMonths     int    `json:"term_months"`
+	StartDate      string `json:"start_date"`
+
+	InitialPaymentCents int64             `json:"initial_payment_cents"`
+	LevelPaymentCents   int64             `json:"level_payment_cents"`
+	LastPaymentCents    int64             `json:"last_payment_cents"`
+	Steps               []GraduatedStepV1 `json:"steps"`
+	TotalInterestCents  int64             `json:"total_interest_cents"`
+	TotalPaidCents      int64             `json:"total_paid_cents"`
+
+	NegativeAmortization            bool  `json:"negative_amortization"`
+	NegativeAmortizationPeriods     int   `json:"negative_amortization_periods"`
+	FirstNegativeAmortizationPeriod int   `json:"first_negative_amortization_period"`
+	LastNegativeAmortizationPeriod  int   `json:"last_negative_amortization_period"`
+	DeferredInterestCents           int64 `json:"deferred_interest_cents"`
+	MaxBalanceCents                 int64 `json:"max_balance_cents"`
+	MaxBalancePeriod                int   `json:"max_balance_period"`
+}
+
+// GraduatedStepV1 is one payment step: the scheduled payment from Period on.
+type GraduatedStepV1 struct {
+	Step         int    `json:"step"`
+	Period       int    `json:"period"`
+	Date         string `json:"date"`
+	PaymentCents int64  `json:"payment_cents"`
+}
+
+// GraduatedRow is one graduated schedule row. PrincipalCents is negative in
+// a negative amortization period, when NegativeAmortization is set.
+type GraduatedRow struct {
+	Period               int
+	Date                 string
+	Step                 int
+	PaymentCents         int64
+	PrincipalCents       int64
+	InterestCents        int64
+	BalanceCents         int64
+	NegativeAmortization bool
+}
@@ -0,0 +1,64 @@
+package calc
+
+// macrsTables holds the IRS GDS percentage tables (Publication 946,
+// Appendix A) in thousandths of a percent, keyed by convention table and
+// recovery class. Tables A-2 to A-5 are mid-quarter for property placed in
+// service in quarters 1 to 4.
+//
+// The published percentages are rounded, so a few tables sum to slightly
+// less than 100%; DepreciationV1 sweeps the remainder into the last year.
+var macrsTables = map[string]map[int][]int64{
+	"A-1": {
+		3:  {33330, 44450, 14810, 7410},
+		5:  {20000, 32000, 19200, 11520, 11520, 5760},
+		7:  {14290, 24490, 17490, 12490, 8930, 8920, 8930, 4460},
+		10: {10000, 18000, 14400, 11520, 9220, 7370, 6550, 6550, 6560, 6550, 3280},
+		15: {5000, 9500, 8550, 7700, 6930, 6230, 5900, 5900, 5910, 5900, 5910, 5900, 5910, 5900, 5910, 2950},
+		20: {3750, 7219, 6677, 6177, 5713, 5285, 4888, 4522, 4462, 4461, 4462, 4461, 4462, 4461, 4462, 4461, 4462, 4461, 4462, 4461, 2231},
+	},
+	"A-2": {
+		3:  {58330, 27780, 12350, 1540},
+		5:  {35000, 26000, 15600, 11010, 11010, 1380},
+		7:  {25000, 21430, 15310, 10930, 8750, 8740, 8750, 1090},
+		10: {17500, 16500, 13200, 10560, 8450, 6760, 6550, 6550, 6560, 6550, 820},
+		15: {8750, 9130, 8210, 7390, 6650, 5990, 5900, 5910, 5900, 5910, 5900, 5910, 5900, 5910, 5900, 740},
+		20: {6563, 7000, 6482, 5996, 5546, 5130, 4746, 4459, 4459, 4459, 4459, 4460, 4459, 4460, 4459, 4460, 4459, 4460, 4459, 4460, 565},
+	},
+	"A-3": {
+		3:  {41670, 38890, 14140, 5300},
+		5:  {25000, 30000, 18000, 11370, 11370, 4260},
+		7:  {17850, 23470, 16760, 11970, 8870, 8870, 8870, 3330},
+		10: {12500, 17500, 14000, 11200, 8960, 7170, 6550, 6550, 6560, 6550, 2460},
+		15: {6250, 9380, 8440, 7590, 6830, 6150, 5910, 5900, 5910, 5900, 5910, 5900, 5910, 5900, 5910, 2210},
+		20: {4688, 7148, 6612, 6116, 5658, 5233, 4841, 4478, 4463, 4463, 4463, 4463, 4463, 4463, 4462, 4463, 4462, 4463, 4462, 4463, 1673},
+	},
+	"A-4": {
+		3:  {25000, 50000, 16670, 8330},
+		5:  {15000, 34000, 20400, 12240, 11300, 7060},
+		7:  {10710, 25510, 18220, 13020, 9300, 8850, 8860, 5530},
+		10: {7500, 18500, 14800, 11840, 9470, 7580, 6550, 6550, 6560, 6550, 4100},
+		15: {3750, 9630, 8660, 7800, 7020, 6310, 5900, 5900, 5910, 5900, 5910, 5900, 5910, 5900, 5910, 3690},
+		20: {2813, 7289, 6742, 6237, 5769, 5336, 4936, 4566, 4460, 4460, 4460, 4460, 4461, 4460, 4461, 4460, 4461, 4460, 4461, 4460, 2788},
+	},
+	"A-5": {
+		3:  {8330, 61110, 20370, 10190},
+		5:  {5000, 38000, 22800, 13680, 10940, 9580},
+		7:  {3570, 27550, 19680, 14060, 10040, 8730, 8730, 7640},
+		10: {2500, 19500, 15600, 12480, 9980, 7990, 6550, 6550, 6560, 6550, 5740},
+		15: {1250, 9880, 8890, 8000, 7200, 6480, 5900, 5900, 5900, 5910, 5900, 5910, 5900, 5910, 5900, 5170},
+		20: {938, 7430, 6872, 6357, 5880, 5439, 5031, 4654, 4458, 4458, 4458, 4458, 4458, 4458, 4458, 4458, 4458, 4458, 4458, 4458, 3901},
+	},
+}
+
+// macrsPctDenom is the denominator of the macrsTables entries (100% =
+// 100000).
+const macrsPctDenom = int64(100_000)
+
+// macrsTable returns the GDS table name for convention and the 1-based
+// calendar quarter the property was placed in service.
+func macrsTable(convention string, quarter int) string {
+	if convention == MACRSConventionMidQuarter {
+		return []string{"A-2", "A-3", "A-4", "A-5"}[quarter-1]
+	}
+	return "A-1"
+}
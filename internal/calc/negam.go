@@ -0,0 +1,200 @@
+package calc
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const calcNameNegAmV1 = "negam"
+
+// Defaults for NegAmRequestV1 fields left at zero.
+const (
+	DefaultNegAmPaymentChangeMonths = 12
+	DefaultNegAmMaxBalanceBps       = int64(11000)
+)
+
+// MaxNegAmBalanceBps bounds max_balance_bps (200% of the original
+// principal), which keeps every balance inside int64 cents.
+const MaxNegAmBalanceBps = int64(20000)
+
+// NegAmV1 computes a payment-option schedule. Unlike a monthly AmortizeV1
+// schedule, the borrower pays a minimum payment that may not cover the
+// interest:
+// - principal is payment minus interest and is negative when the payment falls short; the shortfall is deferred interest added to the balance
+// - every payment_change_months the payment rises by payment_cap_bps, rounded half-up, but never above the fully amortizing payment over the months left
+// - if a payment would leave the balance above max_balance_bps of the original principal, that payment recasts to the fully amortizing payment over the months left (this month included), as does every recast_every_months payment
+//
+// After any recast the loan is fully amortizing; payment changes stop and
+// only scheduled recasts recompute the payment.
+func NegAmV1(req NegAmRequestV1) (NegAmResponseV1, []NegAmRow, error) {
+	if err := validateNegAmReq(req); err != nil {
+		return NegAmResponseV1{}, nil, err
+	}
+	start, _ := time.Parse("2006-01-02", req.StartDate)
+	start = start.UTC()
+	changeMonths := req.PaymentChangeMonths
+	if changeMonths == 0 {
+		changeMonths = DefaultNegAmPaymentChangeMonths
+	}
+	maxBps := req.MaxBalanceBps
+	if maxBps == 0 {
+		maxBps = DefaultNegAmMaxBalanceBps
+	}
+
+	full, err := scheduledPaymentCents(req.PrincipalCents, req.AnnualRateBps, req.TermMonths, monthsPerYr)
+	if err != nil {
+		return NegAmResponseV1{}, nil, err
+	}
+	// A balance exceeds the limit when balance * 10000 > principal * maxBps.
+	limit, err := mulInt64(req.PrincipalCents, maxBps)
+	if err != nil {
+		return NegAmResponseV1{}, nil, err
+	}
+	resp := NegAmResponseV1{
+		SchemaVersion:               schemaV1,
+		Calculator:                  calcNameNegAmV1,
+		PrincipalCents:              req.PrincipalCents,
+		AnnualRateBps:               req.AnnualRateBps,
+		TermMonths:                  req.TermMonths,
+		StartDate:                   req.StartDate,
+		MinimumPaymentCents:         req.MinimumPaymentCents,
+		PaymentCapBps:               req.PaymentCapBps,
+		PaymentChangeMonths:         changeMonths,
+		MaxBalanceBps:               maxBps,
+		RecastEveryMonths:           req.RecastEveryMonths,
+		FullyAmortizingPaymentCents: full,
+		PeakBalanceCents:            req.PrincipalCents,
+		Events:                      make([]NegAmEventV1, 0),
+	}
+	if resp.MaxBalanceCents, err = roundDivHalfUp(limit, bpsDenom); err != nil {
+		return NegAmResponseV1{}, nil, err
+	}
+
+	rows := make([]NegAmRow, 0, req.TermMonths)
+	bal, pmt, amortizing := req.PrincipalCents, req.MinimumPaymentCents, false
+	for i := 1; i <= req.TermMonths && bal > 0; i++ {
+		row := NegAmRow{Period: i, Date: addMonthsClamped(start, i-1).Format("2006-01-02")}
+		left := req.TermMonths - i + 1
+		// A payment change and a max-balance recast can fall in the same
+		// month; both are recorded, in order, and the row shows the last.
+		record := func(event string) {
+			row.Event = event
+			resp.Events = append(resp.Events, NegAmEventV1{Period: i, Date: row.Date, Event: event, BalanceCents: bal, PaymentCents: pmt})
+		}
+
+		switch {
+		case req.RecastEveryMonths > 0 && i > 1 && (i-1)%req.RecastEveryMonths == 0:
+			if pmt, err = scheduledPaymentCents(bal, req.AnnualRateBps, left, monthsPerYr); err != nil {
+				return NegAmResponseV1{}, nil, err
+			}
+			record(NegAmEventRecastScheduled)
+			amortizing = true
+		case !amortizing && i > 1 && (i-1)%changeMonths == 0:
+			raised, err := mulInt64(pmt, bpsDenom+req.PaymentCapBps)
+			if err != nil {
+				return NegAmResponseV1{}, nil, err
+			}
+			if raised, err = roundDivHalfUp(raised, bpsDenom); err != nil {
+				return NegAmResponseV1{}, nil, err
+			}
+			amortized, err := scheduledPaymentCents(bal, req.AnnualRateBps, left, monthsPerYr)
+			if err != nil {
+				return NegAmResponseV1{}, nil, err
+			}
+			if next := min(raised, amortized); next != pmt {
+				pmt = next
+				record(NegAmEventPaymentChange)
+			}
+		}
+
+		if row.InterestCents, err = interestCents(bal, req.AnnualRateBps, monthsPerYr); err != nil {
+			return NegAmResponseV1{}, nil, err
+		}
+		owed, err := addInt64(bal, row.InterestCents)
+		if err != nil {
+			return NegAmResponseV1{}, nil, err
+		}
+		next := owed - pmt
+		if scaled, err := mulInt64(next, bpsDenom); err != nil || scaled > limit {
+			if pmt, err = scheduledPaymentCents(bal, req.AnnualRateBps, left, monthsPerYr); err != nil {
+				return NegAmResponseV1{}, nil, err
+			}
+			record(NegAmEventRecastMaxBalance)
+			amortizing = true
+		}
+
+		row.PaymentCents = pmt
+		row.PrincipalCents = pmt - row.InterestCents
+		if row.PrincipalCents > bal || i == req.TermMonths {
+			row.PrincipalCents = bal
+			if row.PaymentCents, err = addInt64(bal, row.InterestCents); err != nil {
+				return NegAmResponseV1{}, nil, err
+			}
+		}
+		if row.PrincipalCents < 0 {
+			row.DeferredInterestCents = -row.PrincipalCents
+			resp.NegativeAmortizationPeriods++
+			if resp.TotalDeferredInterestCents, err = addInt64(resp.TotalDeferredInterestCents, row.DeferredInterestCents); err != nil {
+				return NegAmResponseV1{}, nil, err
+			}
+		}
+		bal -= row.PrincipalCents
+		row.BalanceCents = bal
+		if bal > resp.PeakBalanceCents {
+			resp.PeakBalanceCents, resp.PeakBalancePeriod = bal, i
+		}
+		rows = append(rows, row)
+	}
+
+	for _, r := range rows {
+		if resp.TotalInterestCents, err = addInt64(resp.TotalInterestCents, r.InterestCents); err != nil {
+			return NegAmResponseV1{}, nil, err
+		}
+		if resp.TotalPaidCents, err = addInt64(resp.TotalPaidCents, r.PaymentCents); err != nil {
+			return NegAmResponseV1{}, nil, err
+		}
+	}
+	resp.LastPaymentCents = rows[len(rows)-1].PaymentCents
+	return resp, rows, nil
+}
+
+func validateNegAmReq(req NegAmRequestV1) error {
+	if req.PrincipalCents <= 0 {
+		return errors.New("principal_cents must be > 0")
+	}
+	if req.PrincipalCents > MaxPrincipalCents {
+		return fmt.Errorf("principal_cents must be <= %d", MaxPrincipalCents)
+	}
+	if req.TermMonths <= 0 {
+		return errors.New("term_months must be > 0")
+	}
+	if req.TermMonths > MaxTermMonths {
+		return fmt.Errorf("term_months must be <= %d", MaxTermMonths)
+	}
+	if req.AnnualRateBps < 0 {
+		return errors.New("annual_rate_bps must be >= 0")
+	}
+	if req.AnnualRateBps > MaxAnnualRateBps {
+		return fmt.Errorf("annual_rate_bps must be <= %d", MaxAnnualRateBps)
+	}
+	if _, err := time.Parse("2006-01-02", req.StartDate); err != nil {
+		return fmt.Errorf("start_date must be YYYY-MM-DD: %w", err)
+	}
+	if req.MinimumPaymentCents <= 0 || req.MinimumPaymentCents > MaxPrincipalCents {
+		return fmt.Errorf("minimum_payment_cents must be between 1 and %d", MaxPrincipalCents)
+	}
+	if req.PaymentCapBps < 0 || req.PaymentCapBps > bpsDenom {
+		return fmt.Errorf("payment_cap_bps must be between 0 and %d", bpsDenom)
+	}
+	if req.PaymentChangeMonths < 0 || req.PaymentChangeMonths > req.TermMonths {
+		return errors.New("payment_change_months must be between 1 and term_months (0 means 12)")
+	}
+	if req.MaxBalanceBps != 0 && (req.MaxBalanceBps < bpsDenom || req.MaxBalanceBps > MaxNegAmBalanceBps) {
+		return fmt.Errorf("max_balance_bps must be between %d and %d", bpsDenom, MaxNegAmBalanceBps)
+	}
+	if req.RecastEveryMonths < 0 || req.RecastEveryMonths > req.TermMonths {
+		return errors.New("recast_every_months must be between 1 and term_months, or 0 for none")
+	}
+	return nil
+}
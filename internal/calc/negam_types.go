@@ -0,0 +1,98 @@
+package calc
+
+// Negative amortization schedule events, reported in NegAmRow.Event and
+// NegAmEventV1.Event.
+const (
+	NegAmEventPaymentChange    = "payment_change"
+	NegAmEventRecastMaxBalance = "recast_max_balance"
+	NegAmEventRecastScheduled  = "recast_scheduled"
+)
+
+// NegAmRequestV1 is the input contract for the v1 negative amortization
+// (minimum-payment) calculator.
+//
+// Money is integer cents and rates are basis points, as in
+// AmortizeRequestV1. Payments are monthly from StartDate and interest
+// accrues at rate / 12 (30/360).
+//
+// The borrower pays MinimumPaymentCents, which may be less than the
+// month's interest; the unpaid interest is added to the balance. Every
+// PaymentChangeMonths (default 12) the minimum payment rises by at most
+// PaymentCapBps. The loan recasts to the fully amortizing payment over the
+// months left when the balance would exceed MaxBalanceBps (default 11000,
+// i.e. 110%) of the original principal, and every RecastEveryMonths when
+// set.
+type NegAmRequestV1 struct {
+	PrincipalCents int64  `json:"principal_cents"`
+	AnnualRateBps  int64  `json:"annual_rate_bps"`
+	TermMonths     int    `json:"term_months"`
+	StartDate      string `json:"start_date"`
+
+	MinimumPaymentCents int64 `json:"minimum_payment_cents"`
+	PaymentCapBps       int64 `json:"payment_cap_bps,omitempty"`
+	PaymentChangeMonths int   `json:"payment_change_months,omitempty"`
+	MaxBalanceBps       int64 `json:"max_balance_bps,omitempty"`
+	RecastEveryMonths   int   `json:"recast_every_months,omitempty"`
+}
+
+// NegAmResponseV1 is the versioned JSON response for the v1 negative
+// amortization calculator.
+//
+// Notes:
+// - max_balance_cents is the recast limit: max_balance_bps of principal_cents
+// - fully_amortizing_payment_cents is the level payment on the original loan, for comparison
+// - events lists every payment change and recast in schedule order
+// - total_deferred_interest_cents is the interest added to the balance; total_paid_cents = principal_cents + total_interest_cents
+type NegAmResponseV1 struct {
+	SchemaVersion string `json:"schema_version"`
+	Calculator    string `json:"calculator"`
+
+	PrincipalCents      int64  `json:"principal_cents"`
+	AnnualRateBps       int64  `json:"annual_rate_bps"`
+	TermMonths          int    `json:"term_months"`
+	StartDate           string `json:"start_date"`
+	MinimumPaymentCents int64  `json:"minimum_payment_cents"`
+	PaymentCapBps       int64  `json:"payment_cap_bps"`
+	PaymentChangeMonths int    `json:"payment_change_months"`
+	MaxBalanceBps       int64  `json:"max_balance_bps"`
+	MaxBalanceCents     int64  `json:"max_balance_cents"`
+	RecastEveryMonths   int    `json:"recast_every_months"`
+
+	FullyAmortizingPaymentCents int64 `json:"fully_amortizing_payment_cents"`
+	LastPaymentCents            int64 `json:"last_payment_cents"`
+	NegativeAmortizationPeriods int   `json:"negative_amortization_periods"`
+	TotalDeferredInterestCents  int64 `json:"total_deferred_interest_cents"`
+	PeakBalanceCents            int64 `json:"peak_balance_cents"`
+	PeakBalancePeriod           int   `json:"peak_balance_period"`
+	TotalInterestCents          int64 `json:"total_interest_cents"`
+	TotalPaidCents              int64 `json:"total_paid_cents"`
+
+	Events []NegAmEventV1 `json:"events"`
+}
+
+// NegAmEventV1 records a payment change or recast and the payment from
+// Period on.
+type NegAmEventV1 struct {
+	Period       int    `json:"period"`
+	Date         string `json:"date"`
+	Event        string `json:"event"`
+	BalanceCents int64  `json:"balance_cents"`
+	PaymentCents int64  `json:"payment_cents"`
+}
+
+// NegAmRow is one negative amortization schedule row. PrincipalCents is
+// signed: negative when the payment is below the interest, and then
+// DeferredInterestCents = -PrincipalCents. Event is empty or the event
+// that set this row's payment. Each row ties out:
+// - PaymentCents = PrincipalCents + InterestCents
+// - previous balance - PrincipalCents = BalanceCents
+type NegAmRow struct {
+	Period                int
+	Date                  string
+	Event                 string
+	PaymentCents          int64
+	InterestCents         int64
+	PrincipalCents        int64
+	DeferredInterestCents int64
+	BalanceCents          int64
+}
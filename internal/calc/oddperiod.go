@@ -0,0 +1,80 @@
+package calc
+
+import (
+	"math/big"
+	"time"
+)
+
+// Odd first-period interest treatments accepted in
+// AmortizeRequestV1.OddInterest.
+//
+// When a loan funds on a date other than one regular period before the first
+// payment, the days between funding_date and that regular period start are
+// "odd days". Interest on them (negative for a short first period) is either
+// added to the first payment or, for a long first period, capitalized into
+// the balance that is amortized.
+const (
+	OddInterestAddToFirstPayment = "add_to_first_payment"
+	OddInterestCapitalize        = "capitalize"
+)
+
+func validOddInterest(t string) bool {
+	switch t {
+	case "", OddInterestAddToFirstPayment, OddInterestCapitalize:
+		return true
+	}
+	return false
+}
+
+// oddDays counts the odd days from funding to regularStart under the
+// request's day-count convention: 30/360 counts days360, the actual
+// conventions count calendar days. It is negative when funding falls after
+// regularStart (a short first period).
+func oddDays(dayCount string, funding, regularStart time.Time) int64 {
+	if funding.After(regularStart) {
+		return -oddDays(dayCount, regularStart, funding)
+	}
+	if dayCount == "" || dayCount == DayCount30360 {
+		return days360(funding, regularStart)
+	}
+	return daysBetween(funding, regularStart)
+}
+
+// oddInterestCents returns interest on balanceCents for the odd days between
+// funding and regularStart, rounded half-up in magnitude (so a short first
+// period yields the exact negative of the matching long-period amount).
+func oddInterestCents(balanceCents, annualRateBps int64, dayCount string, funding, regularStart time.Time) (int64, error) {
+	from, to, sign := funding, regularStart, int64(1)
+	if funding.After(regularStart) {
+		from, to, sign = regularStart, funding, -1
+	}
+	if annualRateBps == 0 || !from.Before(to) {
+		return 0, nil
+	}
+	yf := big.NewRat(days360(from, to), 360)
+	if dayCount != "" && dayCount != DayCount30360 {
+		yf = yearFraction(dayCount, from, to)
+	}
+	i := new(big.Rat).SetFrac(big.NewInt(balanceCents), big.NewInt(bpsDenom))
+	i.Mul(i, new(big.Rat).SetInt64(annualRateBps))
+	i.Mul(i, yf)
+	v, err := roundRatHalfUpToInt64(i)
+	if err != nil {
+		return 0, err
+	}
+	return sign * v, nil
+}
+
+// days360 counts days between a and b (a <= b) on the US 30/360 basis:
+// day 31 counts as day 30, and an end date on the 31st counts as the 30th
+// when the start date is the 30th or 31st.
+func days360(a, b time.Time) int64 {
+	d1, d2 := a.Day(), b.Day()
+	if d1 == 31 {
+		d1 = 30
+	}
+	if d2 == 31 && d1 == 30 {
+		d2 = 30
+	}
+	return int64(360*(b.Year()-a.Year()) + 30*(int(b.Month())-int(a.Month())) + d2 - d1)
+}
@@ -0,0 +1,117 @@
+package calc
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+const calcNamePayoffV1 = "payoff"
+
+// PayoffV1 quotes the amount that pays a loan off on payoff_date.
+func PayoffV1(req PayoffRequestV1) (PayoffResponseV1, []ScheduleRow, error) {
+	return PayoffV1WithCalendar(req, nil)
+}
+
+// PayoffV1WithCalendar quotes a payoff using cal for business-day date
+// rolls (as AmortizeV1WithCalendar).
+//
+// The calculator walks the amortization schedule through payments_made
+// installments and accrues simple interest on the remaining principal from
+// the interest paid-through date to payoff_date, under the loan's day count
+// and exactly as the odd first period accrues (30/360 counts days360). The
+// accrued interest is rounded half-up once; it is not per_diem_cents times
+// the days. The per diem is one day's interest on the principal balance
+// (annual rate / 360, or / 365 for actual/365, or / the days in payoff_date's
+// year for actual/actual), rounded half-up.
+//
+// The rows returned are the installments paid.
+func PayoffV1WithCalendar(req PayoffRequestV1, cal *HolidayCalendar) (PayoffResponseV1, []ScheduleRow, error) {
+	_, rows, err := AmortizeV1WithCalendar(req.AmortizeRequestV1, cal)
+	if err != nil {
+		return PayoffResponseV1{}, nil, err
+	}
+	payoff, err := time.Parse("2006-01-02", req.PayoffDate)
+	if err != nil {
+		return PayoffResponseV1{}, nil, fmt.Errorf("payoff_date must be YYYY-MM-DD: %w", err)
+	}
+	payoff = payoff.UTC()
+
+	// Interest starts at funding, or one period before the first payment.
+	plan := newAmortizePlan(req.AmortizeRequestV1, cal)
+	paidThrough := plan.dueDate(0)
+	if req.FundingDate != "" {
+		paidThrough, _ = time.Parse("2006-01-02", req.FundingDate)
+	}
+	if payoff.Before(paidThrough) {
+		return PayoffResponseV1{}, nil, fmt.Errorf("payoff_date must be on or after %s, when interest starts", paidThrough.Format("2006-01-02"))
+	}
+
+	due := 0
+	for due < len(rows) && rows[due].Date <= req.PayoffDate {
+		due++
+	}
+	if req.PaymentsMade < 0 || req.PaymentsMade > due {
+		return PayoffResponseV1{}, nil, fmt.Errorf("payments_made must be between 0 and %d, the installments due by payoff_date", due)
+	}
+	if req.PaymentsMade == len(rows) {
+		return PayoffResponseV1{}, nil, errors.New("payments_made covers the whole schedule; the loan is already paid off")
+	}
+
+	balance := req.PrincipalCents
+	if req.PaymentsMade > 0 {
+		last := rows[req.PaymentsMade-1]
+		balance = last.BalanceCents
+		paidThrough, _ = time.Parse("2006-01-02", last.Date)
+	}
+	accrued, err := oddInterestCents(balance, req.AnnualRateBps, req.DayCount, paidThrough, payoff)
+	if err != nil {
+		return PayoffResponseV1{}, nil, err
+	}
+	perDiem, err := perDiemCents(balance, req.AnnualRateBps, req.DayCount, payoff)
+	if err != nil {
+		return PayoffResponseV1{}, nil, err
+	}
+	amount, err := addInt64(balance, accrued)
+	if err != nil {
+		return PayoffResponseV1{}, nil, err
+	}
+
+	resp := PayoffResponseV1{
+		SchemaVersion:           schemaV1,
+		Calculator:              calcNamePayoffV1,
+		PayoffDate:              req.PayoffDate,
+		PaymentsMade:            req.PaymentsMade,
+		InstallmentsPastDue:     due - req.PaymentsMade,
+		InterestPaidThroughDate: paidThrough.Format("2006-01-02"),
+		GoodThroughDate:         req.PayoffDate,
+		PrincipalBalanceCents:   balance,
+		AccruedDays:             oddDays(req.DayCount, paidThrough, payoff),
+		AccruedInterestCents:    accrued,
+		PerDiemCents:            perDiem,
+		PayoffAmountCents:       amount,
+	}
+	// The quote holds until the next installment falls due.
+	if due < len(rows) {
+		next, _ := time.Parse("2006-01-02", rows[due].Date)
+		resp.NextDueDate = rows[due].Date
+		resp.GoodThroughDate = next.AddDate(0, 0, -1).Format("2006-01-02")
+	}
+	return resp, rows[:req.PaymentsMade], nil
+}
+
+// perDiemCents is one day's interest on balanceCents under dayCount,
+// rounded half-up.
+func perDiemCents(balanceCents, annualRateBps int64, dayCount string, on time.Time) (int64, error) {
+	basis := int64(360)
+	switch dayCount {
+	case DayCountActual365:
+		basis = 365
+	case DayCountActualActual:
+		basis = daysInYear(on.Year())
+	}
+	r := big.NewRat(balanceCents, bpsDenom*basis)
+	r.Mul(r, new(big.Rat).SetInt64(annualRateBps))
+	return roundRatHalfUpToInt64(r)
+}
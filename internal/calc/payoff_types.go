@@ -0,0 +1,39 @@
+package calc
+
+// PayoffRequestV1 is the input contract for the v1 payoff quote calculator.
+//
+// It takes every AmortizeRequestV1 field, a PayoffDate and PaymentsMade,
+// the number of scheduled installments the borrower has paid. Installments
+// are paid in schedule order, so the loan's principal balance is the
+// schedule balance after PaymentsMade rows. PaymentsMade may not exceed the
+// installments due on or before PayoffDate.
+type PayoffRequestV1 struct {
+	AmortizeRequestV1
+
+	PayoffDate   string `json:"payoff_date"`
+	PaymentsMade int    `json:"payments_made"`
+}
+
+// PayoffResponseV1 is the versioned JSON response for the v1 payoff quote
+// calculator.
+//
+// Notes:
+// - interest_paid_through_date is the due date of the last installment paid (the interest start before any payment)
+// - payoff_amount_cents = principal_balance_cents + accrued_interest_cents
+// - installments_past_due = installments due on or before payoff_date but not paid
+// - the quote grows by per_diem_cents for each day paid after payoff_date, through good_through_date
+type PayoffResponseV1 struct {
+	SchemaVersion           string `json:"schema_version"`
+	Calculator              string `json:"calculator"`
+	PayoffDate              string `json:"payoff_date"`
+	PaymentsMade            int    `json:"payments_made"`
+	InstallmentsPastDue     int    `json:"installments_past_due"`
+	InterestPaidThroughDate string `json:"interest_paid_through_date"`
+	NextDueDate             string `json:"next_due_date,omitempty"`
+	GoodThroughDate         string `json:"good_through_date"`
+	PrincipalBalanceCents   int64  `json:"principal_balance_cents"`
+	AccruedDays             int64  `json:"accrued_days"`
+	AccruedInterestCents    int64  `json:"accrued_interest_cents"`
+	PerDiemCents            int64  `json:"per_diem_cents"`
+	PayoffAmountCents       int64  `json:"payoff_amount_cents"`
+}
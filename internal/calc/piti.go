@@ -0,0 +1,183 @@
+package calc
+
+import (
+	"errors"
+	"fmt"
+)
+
+const calcNamePitiV1 = "piti"
+
+// DefaultPMICancelLTVBps is the loan-to-value ratio at which PMI cancels
+// when pmi_cancel_ltv_bps is omitted: 78% of the original value, the
+// Homeowners Protection Act's automatic termination point.
+const DefaultPMICancelLTVBps = int64(7800)
+
+// PitiV1 breaks a monthly loan payment into P&I, escrow, PMI and HOA.
+func PitiV1(req PitiRequestV1) (PitiResponseV1, []PitiRow, error) {
+	return PitiV1WithCalendar(req, nil)
+}
+
+// PitiV1WithCalendar breaks a monthly loan payment into P&I, escrow, PMI
+// and HOA, using cal for business-day date rolls (as
+// AmortizeV1WithCalendar).
+//
+// The P&I schedule is AmortizeV1's, unchanged. Each payment also collects
+// 1/12 of the annual property tax and insurance and the monthly HOA dues,
+// each rounded half-up once. PMI is pmi_annual_rate_bps of the original
+// principal over 12, rounded half-up, charged while the balance before the
+// payment is above pmi_cancel_ltv_bps of property_value_cents; once the
+// scheduled balance reaches the threshold PMI never comes back.
+func PitiV1WithCalendar(req PitiRequestV1, cal *HolidayCalendar) (PitiResponseV1, []PitiRow, error) {
+	amort, schedule, err := AmortizeV1WithCalendar(req.AmortizeRequestV1, cal)
+	if err != nil {
+		return PitiResponseV1{}, nil, err
+	}
+	if err := validatePitiReq(req); err != nil {
+		return PitiResponseV1{}, nil, err
+	}
+	cancelLTV := req.PMICancelLTVBps
+	if cancelLTV == 0 {
+		cancelLTV = DefaultPMICancelLTVBps
+	}
+
+	resp := PitiResponseV1{
+		SchemaVersion:      schemaV1,
+		Calculator:         calcNamePitiV1,
+		Amortization:       amort,
+		MonthlyHOACents:    req.MonthlyHOACents,
+		PropertyValueCents: req.PropertyValueCents,
+		PMICancelLTVBps:    cancelLTV,
+	}
+	if resp.MonthlyPropertyTaxCents, err = roundDivHalfUp(req.AnnualPropertyTaxCents, monthsPerYr); err != nil {
+		return PitiResponseV1{}, nil, err
+	}
+	if resp.MonthlyInsuranceCents, err = roundDivHalfUp(req.AnnualInsuranceCents, monthsPerYr); err != nil {
+		return PitiResponseV1{}, nil, err
+	}
+	if resp.MonthlyEscrowCents, err = addInt64(resp.MonthlyPropertyTaxCents, resp.MonthlyInsuranceCents); err != nil {
+		return PitiResponseV1{}, nil, err
+	}
+	premium, err := mulInt64(req.PrincipalCents, req.PMIAnnualRateBps)
+	if err != nil {
+		return PitiResponseV1{}, nil, err
+	}
+	if resp.MonthlyPMICents, err = roundDivHalfUp(premium, bpsDenom*monthsPerYr); err != nil {
+		return PitiResponseV1{}, nil, err
+	}
+
+	// PMI applies while balance / value > cancelLTV / bpsDenom, compared
+	// exactly as balance * bpsDenom > value * cancelLTV.
+	var threshold int64
+	if req.PropertyValueCents > 0 {
+		if threshold, err = mulInt64(req.PropertyValueCents, cancelLTV); err != nil {
+			return PitiResponseV1{}, nil, err
+		}
+		ltv, err := mulInt64(req.PrincipalCents, bpsDenom)
+		if err != nil {
+			return PitiResponseV1{}, nil, err
+		}
+		if resp.OriginalLTVBps, err = roundDivHalfUp(ltv, req.PropertyValueCents); err != nil {
+			return PitiResponseV1{}, nil, err
+		}
+	}
+
+	rows := make([]PitiRow, 0, len(schedule))
+	pmiActive := resp.MonthlyPMICents > 0
+	// The first balance is the schedule's opening balance, which includes
+	// capitalized odd-period interest.
+	balance := schedule[0].BalanceCents + schedule[0].PrincipalCents
+	for _, r := range schedule {
+		row := PitiRow{
+			Period:           r.Period,
+			Date:             r.Date,
+			PaymentCents:     r.PaymentCents,
+			PrincipalCents:   r.PrincipalCents,
+			InterestCents:    r.InterestCents,
+			BalanceCents:     r.BalanceCents,
+			PropertyTaxCents: resp.MonthlyPropertyTaxCents,
+			InsuranceCents:   resp.MonthlyInsuranceCents,
+			HOACents:         req.MonthlyHOACents,
+		}
+		if pmiActive {
+			scaled, err := mulInt64(balance, bpsDenom)
+			if err != nil {
+				return PitiResponseV1{}, nil, err
+			}
+			if scaled > threshold {
+				row.PMICents = resp.MonthlyPMICents
+				resp.PMIPayments++
+			} else {
+				pmiActive = false
+				if resp.PMIPayments > 0 {
+					resp.PMICancelPeriod = r.Period
+					resp.PMICancelDate = r.Date
+				}
+			}
+		}
+		total := row.PaymentCents
+		for _, c := range []int64{row.PropertyTaxCents, row.InsuranceCents, row.PMICents, row.HOACents} {
+			if total, err = addInt64(total, c); err != nil {
+				return PitiResponseV1{}, nil, err
+			}
+		}
+		row.TotalPaymentCents = total
+		rows = append(rows, row)
+		balance = r.BalanceCents
+	}
+	if len(rows) > 0 {
+		resp.InitialTotalPaymentCents = rows[0].TotalPaymentCents
+	}
+
+	for _, r := range rows {
+		for _, t := range []struct {
+			sum *int64
+			v   int64
+		}{
+			{&resp.TotalPropertyTaxCents, r.PropertyTaxCents},
+			{&resp.TotalInsuranceCents, r.InsuranceCents},
+			{&resp.TotalPMICents, r.PMICents},
+			{&resp.TotalHOACents, r.HOACents},
+			{&resp.TotalPaymentsCents, r.TotalPaymentCents},
+		} {
+			if *t.sum, err = addInt64(*t.sum, t.v); err != nil {
+				return PitiResponseV1{}, nil, err
+			}
+		}
+	}
+	if resp.TotalEscrowCents, err = addInt64(resp.TotalPropertyTaxCents, resp.TotalInsuranceCents); err != nil {
+		return PitiResponseV1{}, nil, err
+	}
+	return resp, rows, nil
+}
+
+func validatePitiReq(req PitiRequestV1) error {
+	if req.PaymentFrequency != "" && req.PaymentFrequency != FrequencyMonthly {
+		return errors.New("payment_frequency must be monthly")
+	}
+	for _, f := range []struct {
+		name string
+		v    int64
+	}{
+		{"annual_property_tax_cents", req.AnnualPropertyTaxCents},
+		{"annual_insurance_cents", req.AnnualInsuranceCents},
+		{"monthly_hoa_cents", req.MonthlyHOACents},
+		{"property_value_cents", req.PropertyValueCents},
+	} {
+		if f.v < 0 {
+			return fmt.Errorf("%s must be >= 0", f.name)
+		}
+		if f.v > MaxPrincipalCents {
+			return fmt.Errorf("%s must be <= %d", f.name, MaxPrincipalCents)
+		}
+	}
+	if req.PMIAnnualRateBps < 0 || req.PMIAnnualRateBps > bpsDenom {
+		return fmt.Errorf("pmi_annual_rate_bps must be between 0 and %d", bpsDenom)
+	}
+	if req.PMICancelLTVBps < 0 || req.PMICancelLTVBps > bpsDenom {
+		return fmt.Errorf("pmi_cancel_ltv_bps must be between 1 and %d (0 means %d)", bpsDenom, DefaultPMICancelLTVBps)
+	}
+	if req.PMIAnnualRateBps > 0 && req.PropertyValueCents == 0 {
+		return errors.New("pmi_annual_rate_bps requires property_value_cents")
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package calc
+
+// PitiRequestV1 is the input contract for the v1 PITI (principal,
+// interest, taxes and insurance) payment calculator.
+//
+// It takes every AmortizeRequestV1 field (monthly payments only) and adds
+// the non-P&I parts of the monthly payment. Property tax and homeowners
+// insurance are annual amounts collected in escrow at 1/12 per payment.
+// PMI is an annual premium rate on the original principal, charged monthly
+// until the scheduled balance reaches PMICancelLTVBps of the original
+// PropertyValueCents. HOA dues are a flat monthly amount.
+type PitiRequestV1 struct {
+	AmortizeRequestV1
+
+	AnnualPropertyTaxCents int64 `json:"annual_property_tax_cents,omitempty"`
+	AnnualInsuranceCents   int64 `json:"annual_insurance_cents,omitempty"`
+	MonthlyHOACents        int64 `json:"monthly_hoa_cents,omitempty"`
+
+	PropertyValueCents int64 `json:"property_value_cents,omitempty"`
+	PMIAnnualRateBps   int64 `json:"pmi_annual_rate_bps,omitempty"`
+	PMICancelLTVBps    int64 `json:"pmi_cancel_ltv_bps,omitempty"`
+}
+
+// PitiResponseV1 is the versioned JSON response for the v1 PITI
+// calculator.
+//
+// Notes:
+// - amortization is exactly the /v1/amortize response for the loan; the P&I figures are unchanged
+// - monthly_escrow_cents = monthly_property_tax_cents + monthly_insurance_cents
+// - initial_total_payment_cents is the first payment with every component
+// - pmi_cancel_period is the first payment without PMI after PMI was charged (0 if PMI never cancels or is never charged)
+// - total_payments_cents = amortization.total_paid_cents + total_escrow_cents + total_pmi_cents + total_hoa_cents
+type PitiResponseV1 struct {
+	SchemaVersion string             `json:"schema_version"`
+	Calculator    string             `json:"calculator"`
+	Amortization  AmortizeResponseV1 `json:"amortization"`
+
+	MonthlyPropertyTaxCents  int64 `json:"monthly_property_tax_cents"`
+	MonthlyInsuranceCents    int64 `json:"monthly_insurance_cents"`
+	MonthlyEscrowCents       int64 `json:"monthly_escrow_cents"`
+	MonthlyPMICents          int64 `json:"monthly_pmi_cents"`
+	MonthlyHOACents          int64 `json:"monthly_hoa_cents"`
+	InitialTotalPaymentCents int64 `json:"initial_total_payment_cents"`
+
+	PropertyValueCents int64  `json:"property_value_cents"`
+	OriginalLTVBps     int64  `json:"original_ltv_bps"`
+	PMICancelLTVBps    int64  `json:"pmi_cancel_ltv_bps"`
+	PMIPayments        int    `json:"pmi_payments"`
+	PMICancelPeriod    int    `json:"pmi_cancel_period"`
+	PMICancelDate      string `json:"pmi_cancel_date,omitempty"`
+
+	TotalPropertyTaxCents int64 `json:"total_property_tax_cents"`
+	TotalInsuranceCents   int64 `json:"total_insurance_cents"`
+	TotalEscrowCents      int64 `json:"total_escrow_cents"`
+	TotalPMICents         int64 `json:"total_pmi_cents"`
+	TotalHOACents         int64 `json:"total_hoa_cents"`
+	TotalPaymentsCents    int64 `json:"total_payments_cents"`
+}
+
+// PitiRow is one Amortize v1 schedule row plus the escrow, PMI and HOA
+// collected with it. PaymentCents is still the P&I payment;
+// TotalPaymentCents adds every other component.
+type PitiRow struct {
+	Period            int
+	Date              string
+	PaymentCents      int64
+	PrincipalCents    int64
+	InterestCents     int64
+	BalanceCents      int64
+	PropertyTaxCents  int64
+	InsuranceCents    int64
+	PMICents          int64
+	HOACents          int64
+	TotalPaymentCents int64
+}
@@ -0,0 +1,110 @@
+package calc
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+const calcNamePrecomputedV1 = "precomputed"
+
+// PrecomputedV1 splits the finance charge of a precomputed-interest
+// contract into earned and unearned interest and quotes the payoff after
+// payoff_period payments.
+//
+// The contract schedule is AmortizeV1's, unchanged, so the finance charge
+// is exactly the schedule's total interest. After k of n payments the
+// unearned interest is:
+// - actuarial: the interest of the payments not yet made, i.e. the payoff is the amortized balance
+// - rule_of_78s: the finance charge times (n-k)(n-k+1) / (n(n+1)), computed exactly and rounded half-up
+//
+// The rebate is the unearned interest; the payoff is the remaining
+// scheduled payments less the rebate. No acquisition fee or minimum
+// finance charge is retained.
+func PrecomputedV1(req PrecomputedRequestV1) (PrecomputedResponseV1, []PrecomputedRow, error) {
+	amort, schedule, err := AmortizeV1(AmortizeRequestV1{
+		PrincipalCents: req.PrincipalCents,
+		AnnualRateBps:  req.AnnualRateBps,
+		TermMonths:     req.TermMonths,
+		StartDate:      req.StartDate,
+	})
+	if err != nil {
+		return PrecomputedResponseV1{}, nil, err
+	}
+	if req.Method != RebateRuleOf78s && req.Method != RebateActuarial {
+		return PrecomputedResponseV1{}, nil, errors.New("method must be one of rule_of_78s, actuarial")
+	}
+	n := len(schedule)
+	if req.PayoffPeriod < 0 || req.PayoffPeriod > n {
+		return PrecomputedResponseV1{}, nil, fmt.Errorf("payoff_period must be between 0 and %d", n)
+	}
+
+	fc := amort.TotalInterestCents
+	actuarial := make([]int64, n+1)
+	rule78 := make([]int64, n+1)
+	actuarial[0], rule78[0] = fc, fc
+	for k := 1; k <= n; k++ {
+		actuarial[k] = actuarial[k-1] - schedule[k-1].InterestCents
+		if rule78[k], err = ruleOf78sUnearnedCents(fc, n, k); err != nil {
+			return PrecomputedResponseV1{}, nil, err
+		}
+	}
+	unearned := actuarial
+	if req.Method == RebateRuleOf78s {
+		unearned = rule78
+	}
+
+	rows := make([]PrecomputedRow, 0, n)
+	remaining := amort.TotalPaidCents
+	for k, r := range schedule {
+		remaining -= r.PaymentCents
+		rows = append(rows, PrecomputedRow{
+			Period:                 r.Period,
+			Date:                   r.Date,
+			PaymentCents:           r.PaymentCents,
+			EarnedInterestCents:    unearned[k] - unearned[k+1],
+			UnearnedInterestCents:  unearned[k+1],
+			RemainingPaymentsCents: remaining,
+			PayoffCents:            remaining - unearned[k+1],
+		})
+	}
+
+	k := req.PayoffPeriod
+	resp := PrecomputedResponseV1{
+		SchemaVersion:         schemaV1,
+		Calculator:            calcNamePrecomputedV1,
+		PrincipalCents:        req.PrincipalCents,
+		AnnualRateBps:         req.AnnualRateBps,
+		TermMonths:            req.TermMonths,
+		StartDate:             req.StartDate,
+		Method:                req.Method,
+		PayoffPeriod:          k,
+		PaymentCents:          amort.PaymentCents,
+		FinalPaymentCents:     schedule[n-1].PaymentCents,
+		TotalOfPaymentsCents:  amort.TotalPaidCents,
+		FinanceChargeCents:    fc,
+		EarnedInterestCents:   fc - unearned[k],
+		UnearnedInterestCents: unearned[k],
+		RebateCents:           unearned[k],
+		ActuarialRebateCents:  actuarial[k],
+		RuleOf78sRebateCents:  rule78[k],
+		RuleOf78sPenaltyCents: actuarial[k] - rule78[k],
+	}
+	resp.RemainingPaymentsCents = amort.TotalPaidCents
+	if k > 0 {
+		resp.RemainingPaymentsCents = rows[k-1].RemainingPaymentsCents
+	}
+	resp.PaymentsMadeCents = amort.TotalPaidCents - resp.RemainingPaymentsCents
+	resp.PayoffCents = resp.RemainingPaymentsCents - resp.RebateCents
+	return resp, rows, nil
+}
+
+// ruleOf78sUnearnedCents is the Rule of 78s unearned share of fc after k of
+// n payments: the sum of the digits of the n-k payments left over the sum
+// of all n digits.
+func ruleOf78sUnearnedCents(fc int64, n, k int) (int64, error) {
+	left := int64(n - k)
+	r := new(big.Rat).SetInt64(fc)
+	r.Mul(r, big.NewRat(left*(left+1), int64(n)*int64(n+1)))
+	return roundRatHalfUpToInt64(r)
+}
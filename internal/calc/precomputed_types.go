@@ -0,0 +1,77 @@
+package calc
+
+// Rebate methods accepted in PrecomputedRequestV1.Method.
+const (
+	RebateRuleOf78s = "rule_of_78s"
+	RebateActuarial = "actuarial"
+)
+
+// PrecomputedRequestV1 is the input contract for the v1 precomputed-interest
+// calculator (consumer installment contracts).
+//
+// Money is integer cents and rates are basis points, as in
+// AmortizeRequestV1. The contract is the monthly AmortizeV1 schedule of
+// PrincipalCents (the amount financed): the borrower owes every scheduled
+// payment and the finance charge is their total less the amount financed.
+//
+// PayoffPeriod is the number of payments made before the loan is paid off
+// (0 to TermMonths). The unearned part of the finance charge is rebated
+// from the remaining payments, split by Method: rule_of_78s or actuarial.
+type PrecomputedRequestV1 struct {
+	PrincipalCents int64  `json:"principal_cents"`
+	AnnualRateBps  int64  `json:"annual_rate_bps"`
+	TermMonths     int    `json:"term_months"`
+	StartDate      string `json:"start_date"`
+
+	Method       string `json:"method"`
+	PayoffPeriod int    `json:"payoff_period"`
+}
+
+// PrecomputedResponseV1 is the versioned JSON response for the v1
+// precomputed-interest calculator.
+//
+// Notes:
+// - finance_charge_cents = total_of_payments_cents - principal_cents = earned_interest_cents + unearned_interest_cents
+// - rebate_cents is the unearned interest under method; payoff_cents = remaining_payments_cents - rebate_cents
+// - actuarial_rebate_cents and rule_of_78s_rebate_cents give both methods at payoff_period; rule_of_78s_penalty_cents is their difference
+type PrecomputedResponseV1 struct {
+	SchemaVersion string `json:"schema_version"`
+	Calculator    string `json:"calculator"`
+
+	PrincipalCents int64  `json:"principal_cents"`
+	AnnualRateBps  int64  `json:"annual_rate_bps"`
+	TermMonths     int    `json:"term_months"`
+	StartDate      string `json:"start_date"`
+	Method         string `json:"method"`
+	PayoffPeriod   int    `json:"payoff_period"`
+
+	PaymentCents           int64 `json:"payment_cents"`
+	FinalPaymentCents      int64 `json:"final_payment_cents"`
+	TotalOfPaymentsCents   int64 `json:"total_of_payments_cents"`
+	FinanceChargeCents     int64 `json:"finance_charge_cents"`
+	PaymentsMadeCents      int64 `json:"payments_made_cents"`
+	RemainingPaymentsCents int64 `json:"remaining_payments_cents"`
+
+	EarnedInterestCents   int64 `json:"earned_interest_cents"`
+	UnearnedInterestCents int64 `json:"unearned_interest_cents"`
+	RebateCents           int64 `json:"rebate_cents"`
+	PayoffCents           int64 `json:"payoff_cents"`
+	ActuarialRebateCents  int64 `json:"actuarial_rebate_cents"`
+	RuleOf78sRebateCents  int64 `json:"rule_of_78s_rebate_cents"`
+	RuleOf78sPenaltyCents int64 `json:"rule_of_78s_penalty_cents"`
+}
+
+// PrecomputedRow is one payment of the contract under the request's
+// method, with the payoff quote if the loan is paid off right after it.
+// Each row ties out:
+// - previous unearned - EarnedInterestCents = UnearnedInterestCents
+// - PayoffCents = RemainingPaymentsCents - UnearnedInterestCents
+type PrecomputedRow struct {
+	Period                 int
+	Date                   string
+	PaymentCents           int64
+	EarnedInterestCents    int64
+	UnearnedInterestCents  int64
+	RemainingPaymentsCents int64
+	PayoffCents            int64
+}
@@ -1,19 +1,31 @@
 package calc
 
-import "math/big"
+import (
+	"errors"
+	"math"
+	"math/big"
+)
 
-func roundRatHalfUpToInt64(r *big.Rat) int64 {
+// errOverflow is returned when an amount does not fit in int64 cents.
+// The request bounds enforced by validateReq keep valid inputs well clear of
+// it; the checks exist so an out-of-range value fails loudly, never wraps.
+var errOverflow = errors.New("amount exceeds int64 cents range")
+
+func roundRatHalfUpToInt64(r *big.Rat) (int64, error) {
 	// r must be non-negative.
 	num := new(big.Int).Set(r.Num())
 	den := new(big.Int).Set(r.Denom())
 	if den.Sign() == 0 {
-		return 0
+		return 0, nil
 	}
 	// (num + den/2) / den
 	half := new(big.Int).Rsh(den, 1)
 	num.Add(num, half)
 	q := new(big.Int).Quo(num, den)
-	return q.Int64()
+	if !q.IsInt64() {
+		return 0, errOverflow
+	}
+	return q.Int64(), nil
 }
 
 func powRat(x *big.Rat, n int) *big.Rat {
@@ -33,10 +45,59 @@ func powRat(x *big.Rat, n int) *big.Rat {
 	return res
 }
 
-func roundDivHalfUp(numer, denom int64) int64 {
+func roundDivHalfUp(numer, denom int64) (int64, error) {
 	// For this repo's use, denom must be > 0 and numer must be >= 0.
 	if denom <= 0 {
-		return 0
+		return 0, nil
+	}
+	// numer + denom/2 can overflow even when the quotient fits.
+	if numer > math.MaxInt64-denom/2 {
+		return roundRatHalfUpToInt64(big.NewRat(numer, denom))
+	}
+	return (numer + denom/2) / denom, nil
+}
+
+// addInt64 returns a+b, or errOverflow if the sum does not fit in int64.
+func addInt64(a, b int64) (int64, error) {
+	c := a + b
+	if (c > a) != (b > 0) {
+		return 0, errOverflow
+	}
+	return c, nil
+}
+
+// mulInt64 returns a*b, or errOverflow if the product does not fit in int64.
+func mulInt64(a, b int64) (int64, error) {
+	if a == 0 || b == 0 {
+		return 0, nil
+	}
+	c := a * b
+	if c/b != a || (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64) {
+		return 0, errOverflow
+	}
+	return c, nil
+}
+
+// formatRatDecimal renders r with exactly places decimals, rounded half-up
+// in magnitude (so -0.0000005 renders as "-0.000001").
+func formatRatDecimal(r *big.Rat, places int) string {
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(places)), nil)
+	v := new(big.Rat).Abs(r)
+	v.Mul(v, new(big.Rat).SetInt(scale))
+	// floor(v + 1/2)
+	v.Add(v, big.NewRat(1, 2))
+	q := new(big.Int).Quo(v.Num(), v.Denom())
+	intPart, frac := new(big.Int).QuoRem(q, scale, new(big.Int))
+	sign := ""
+	if r.Sign() < 0 && q.Sign() != 0 {
+		sign = "-"
+	}
+	if places == 0 {
+		return sign + intPart.String()
+	}
+	f := frac.String()
+	for len(f) < places {
+		f = "0" + f
 	}
-	return (numer + denom/2) / denom
+	return sign + intPart.String() + "." + f
 }
@@ -0,0 +1,137 @@
+package calc
+
+import (
+	"errors"
+	"fmt"
+)
+
+const calcNameRefinanceV1 = "refinance"
+
+// RefinanceV1 compares keeping an existing loan against refinancing it.
+func RefinanceV1(req RefinanceRequestV1) (RefinanceResponseV1, []RefinanceRow, error) {
+	return RefinanceV1WithCalendar(req, nil)
+}
+
+// RefinanceV1WithCalendar compares keeping an existing loan against
+// refinancing it, using cal for business-day date rolls on both loans.
+//
+// Both loans run through AmortizeV1 unchanged, so every figure is a sum of
+// schedule rows. The side-by-side schedule pairs month m of the existing
+// loan's remaining payments with month m of the new loan; the break-even
+// month is the first whose cumulative savings (existing minus new payments)
+// reach closing_costs_cents, whether those costs are paid or rolled in.
+func RefinanceV1WithCalendar(req RefinanceRequestV1, cal *HolidayCalendar) (RefinanceResponseV1, []RefinanceRow, error) {
+	existingResp, existing, err := AmortizeV1WithCalendar(req.Existing, cal)
+	if err != nil {
+		return RefinanceResponseV1{}, nil, fmt.Errorf("existing.%w", err)
+	}
+	if req.Existing.PaymentFrequency != "" && req.Existing.PaymentFrequency != FrequencyMonthly {
+		return RefinanceResponseV1{}, nil, errors.New("existing.payment_frequency must be monthly")
+	}
+	if req.PaymentsMade < 0 || req.PaymentsMade >= len(existing) {
+		return RefinanceResponseV1{}, nil, fmt.Errorf("payments_made must be between 0 and %d", len(existing)-1)
+	}
+	if req.NewAnnualRateBps < 0 || req.NewAnnualRateBps > MaxAnnualRateBps {
+		return RefinanceResponseV1{}, nil, fmt.Errorf("new_annual_rate_bps must be between 0 and %d", MaxAnnualRateBps)
+	}
+	if req.NewTermMonths <= 0 || req.NewTermMonths > MaxTermMonths {
+		return RefinanceResponseV1{}, nil, fmt.Errorf("new_term_months must be between 1 and %d", MaxTermMonths)
+	}
+	if req.ClosingCostsCents < 0 || req.ClosingCostsCents > MaxPrincipalCents {
+		return RefinanceResponseV1{}, nil, fmt.Errorf("closing_costs_cents must be between 0 and %d", MaxPrincipalCents)
+	}
+
+	// Before any payment the balance is the first row's opening balance,
+	// which includes capitalized odd-period interest.
+	balance := existing[0].BalanceCents + existing[0].PrincipalCents
+	if req.PaymentsMade > 0 {
+		balance = existing[req.PaymentsMade-1].BalanceCents
+	}
+	remaining := existing[req.PaymentsMade:]
+
+	newPrincipal := balance
+	outOfPocket := req.ClosingCostsCents
+	if req.RollInClosingCosts {
+		if newPrincipal, err = addInt64(balance, req.ClosingCostsCents); err != nil {
+			return RefinanceResponseV1{}, nil, err
+		}
+		outOfPocket = 0
+	}
+	// The new loan starts on the next contractual due date, not its rolled
+	// row date, so a business-day roll does not move its day of month.
+	next := newAmortizePlan(req.Existing, cal).contractualDate(req.PaymentsMade + 1)
+	newReq := AmortizeRequestV1{
+		PrincipalCents: newPrincipal,
+		AnnualRateBps:  req.NewAnnualRateBps,
+		TermMonths:     req.NewTermMonths,
+		StartDate:      next.Format("2006-01-02"),
+		DateRoll:       req.Existing.DateRoll,
+		DayCount:       req.Existing.DayCount,
+	}
+	newResp, refi, err := AmortizeV1WithCalendar(newReq, cal)
+	if err != nil {
+		return RefinanceResponseV1{}, nil, fmt.Errorf("new loan: %w", err)
+	}
+
+	existingInterest, existingPaid, err := scheduleTotals(remaining)
+	if err != nil {
+		return RefinanceResponseV1{}, nil, err
+	}
+
+	resp := RefinanceResponseV1{
+		SchemaVersion:                  schemaV1,
+		Calculator:                     calcNameRefinanceV1,
+		PaymentsMade:                   req.PaymentsMade,
+		ExistingRemainingBalanceCents:  balance,
+		ExistingRemainingPayments:      len(remaining),
+		ExistingPaymentCents:           existingResp.PaymentCents,
+		ExistingRemainingPaymentsCents: existingPaid,
+		ExistingRemainingInterestCents: existingInterest,
+		NewPrincipalCents:              newPrincipal,
+		NewAnnualRateBps:               req.NewAnnualRateBps,
+		NewTermMonths:                  req.NewTermMonths,
+		NewStartDate:                   newReq.StartDate,
+		NewPaymentCents:                newResp.PaymentCents,
+		NewTotalPaymentsCents:          newResp.TotalPaidCents,
+		NewTotalInterestCents:          newResp.TotalInterestCents,
+		ClosingCostsCents:              req.ClosingCostsCents,
+		RollInClosingCosts:             req.RollInClosingCosts,
+		MonthlySavingsCents:            existingResp.PaymentCents - newResp.PaymentCents,
+		InterestDifferenceCents:        existingInterest - newResp.TotalInterestCents,
+		NetSavingsCents:                existingPaid - newResp.TotalPaidCents - outOfPocket,
+	}
+
+	months := len(remaining)
+	if len(refi) > months {
+		months = len(refi)
+	}
+	rows := make([]RefinanceRow, 0, months)
+	var cumulative int64
+	for m := 0; m < months; m++ {
+		row := RefinanceRow{Month: m + 1}
+		if m < len(remaining) {
+			r := remaining[m]
+			row.ExistingDate = r.Date
+			row.ExistingPaymentCents = r.PaymentCents
+			row.ExistingInterestCents = r.InterestCents
+			row.ExistingBalanceCents = r.BalanceCents
+		}
+		if m < len(refi) {
+			r := refi[m]
+			row.NewDate = r.Date
+			row.NewPaymentCents = r.PaymentCents
+			row.NewInterestCents = r.InterestCents
+			row.NewBalanceCents = r.BalanceCents
+		}
+		if cumulative, err = addInt64(cumulative, row.ExistingPaymentCents-row.NewPaymentCents); err != nil {
+			return RefinanceResponseV1{}, nil, err
+		}
+		row.CumulativeSavingsCents = cumulative
+		if !resp.BreaksEven && cumulative >= req.ClosingCostsCents {
+			resp.BreaksEven = true
+			resp.BreakEvenMonth = m + 1
+		}
+		rows = append(rows, row)
+	}
+	return resp, rows, nil
+}
@@ -0,0 +1,76 @@
+package calc
+
+// RefinanceRequestV1 is the input contract for the v1 refinance break-even
+// calculator.
+//
+// Existing is the current loan as an AmortizeRequestV1 (monthly payments)
+// and PaymentsMade the installments already paid on it; the refinance pays
+// off the schedule balance after them. The new loan is a fixed-rate
+// monthly loan of NewAnnualRateBps over NewTermMonths whose first payment
+// falls on the existing loan's next due date, with the existing loan's
+// date_roll and day_count.
+//
+// ClosingCostsCents are paid out of pocket, or added to the new principal
+// when RollInClosingCosts is set.
+type RefinanceRequestV1 struct {
+	Existing     AmortizeRequestV1 `json:"existing"`
+	PaymentsMade int               `json:"payments_made"`
+
+	NewAnnualRateBps   int64 `json:"new_annual_rate_bps"`
+	NewTermMonths      int   `json:"new_term_months"`
+	ClosingCostsCents  int64 `json:"closing_costs_cents"`
+	RollInClosingCosts bool  `json:"roll_in_closing_costs,omitempty"`
+}
+
+// RefinanceResponseV1 is the versioned JSON response for the v1 refinance
+// calculator.
+//
+// Notes:
+// - monthly_savings_cents = existing_payment_cents - new_payment_cents (negative when the new payment is higher)
+// - break_even_month is the first month whose cumulative payment savings reach closing_costs_cents; 0 with breaks_even false if none does
+// - interest_difference_cents = existing_remaining_interest_cents - new_total_interest_cents
+// - net_savings_cents = existing_remaining_payments_cents - new_total_payments_cents - out-of-pocket closing costs
+type RefinanceResponseV1 struct {
+	SchemaVersion string `json:"schema_version"`
+	Calculator    string `json:"calculator"`
+
+	PaymentsMade                   int   `json:"payments_made"`
+	ExistingRemainingBalanceCents  int64 `json:"existing_remaining_balance_cents"`
+	ExistingRemainingPayments      int   `json:"existing_remaining_payments"`
+	ExistingPaymentCents           int64 `json:"existing_payment_cents"`
+	ExistingRemainingPaymentsCents int64 `json:"existing_remaining_payments_cents"`
+	ExistingRemainingInterestCents int64 `json:"existing_remaining_interest_cents"`
+
+	NewPrincipalCents     int64  `json:"new_principal_cents"`
+	NewAnnualRateBps      int64  `json:"new_annual_rate_bps"`
+	NewTermMonths         int    `json:"new_term_months"`
+	NewStartDate          string `json:"new_start_date"`
+	NewPaymentCents       int64  `json:"new_payment_cents"`
+	NewTotalPaymentsCents int64  `json:"new_total_payments_cents"`
+	NewTotalInterestCents int64  `json:"new_total_interest_cents"`
+
+	ClosingCostsCents       int64 `json:"closing_costs_cents"`
+	RollInClosingCosts      bool  `json:"roll_in_closing_costs"`
+	MonthlySavingsCents     int64 `json:"monthly_savings_cents"`
+	BreaksEven              bool  `json:"breaks_even"`
+	BreakEvenMonth          int   `json:"break_even_month"`
+	InterestDifferenceCents int64 `json:"interest_difference_cents"`
+	NetSavingsCents         int64 `json:"net_savings_cents"`
+}
+
+// RefinanceRow is one month of the side-by-side comparison. A side whose
+// schedule has ended has an empty date and zero amounts.
+// CumulativeSavingsCents is the running total of existing minus new
+// payments.
+type RefinanceRow struct {
+	Month                  int
+	ExistingDate           string
+	ExistingPaymentCents   int64
+	ExistingInterestCents  int64
+	ExistingBalanceCents   int64
+	NewDate                string
+	NewPaymentCents        int64
+	NewInterestCents       int64
+	NewBalanceCents        int64
+	CumulativeSavingsCents int64
+}
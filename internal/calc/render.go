@@ -10,7 +10,131 @@ import (
 // RenderResponseJSON emits a stable, indented JSON representation
 // with a trailing newline (for checked-in fixtures/goldens).
 func RenderResponseJSON(resp AmortizeResponseV1) ([]byte, error) {
-	b, err := json.MarshalIndent(resp, "", "  ")
+	return renderJSON(resp)
+}
+
+// RenderArmResponseJSON emits the ARM response in the same stable JSON form
+// as RenderResponseJSON.
+func RenderArmResponseJSON(resp ArmResponseV1) ([]byte, error) {
+	return renderJSON(resp)
+}
+
+// RenderSolveResponseJSON emits a solver response in the same stable JSON
+// form as RenderResponseJSON.
+func RenderSolveResponseJSON(resp SolveResponseV1) ([]byte, error) {
+	return renderJSON(resp)
+}
+
+// RenderAprResponseJSON emits the APR response in the same stable JSON form
+// as RenderResponseJSON.
+func RenderAprResponseJSON(resp AprResponseV1) ([]byte, error) {
+	return renderJSON(resp)
+}
+
+// RenderNpvResponseJSON emits the NPV response in the same stable JSON form
+// as RenderResponseJSON.
+func RenderNpvResponseJSON(resp NpvResponseV1) ([]byte, error) {
+	return renderJSON(resp)
+}
+
+// RenderIrrResponseJSON emits the IRR response in the same stable JSON form
+// as RenderResponseJSON.
+func RenderIrrResponseJSON(resp IrrResponseV1) ([]byte, error) {
+	return renderJSON(resp)
+}
+
+// RenderXirrResponseJSON emits the XIRR response in the same stable JSON
+// form as RenderResponseJSON.
+func RenderXirrResponseJSON(resp XirrResponseV1) ([]byte, error) {
+	return renderJSON(resp)
+}
+
+// RenderBondResponseJSON emits the bond price or yield response in the same
+// stable JSON form as RenderResponseJSON.
+func RenderBondResponseJSON(resp BondResponseV1) ([]byte, error) {
+	return renderJSON(resp)
+}
+
+// RenderCreditCardResponseJSON emits the credit card payoff projection in
+// the same stable JSON form as RenderResponseJSON.
+func RenderCreditCardResponseJSON(resp CreditCardResponseV1) ([]byte, error) {
+	return renderJSON(resp)
+}
+
+// RenderDefermentResponseJSON emits the deferment summary in the same
+// stable JSON form as RenderResponseJSON.
+func RenderDefermentResponseJSON(resp DefermentResponseV1) ([]byte, error) {
+	return renderJSON(resp)
+}
+
+// RenderDepreciationResponseJSON emits the depreciation response in the
+// same stable JSON form as RenderResponseJSON.
+func RenderDepreciationResponseJSON(resp DepreciationResponseV1) ([]byte, error) {
+	return renderJSON(resp)
+}
+
+// RenderFutureValueResponseJSON emits the lump-sum future value response in
+// the same stable JSON form as RenderResponseJSON.
+func RenderFutureValueResponseJSON(resp FutureValueResponseV1) ([]byte, error) {
+	return renderJSON(resp)
+}
+
+// RenderAnnuityResponseJSON emits the annuity response in the same stable
+// JSON form as RenderResponseJSON.
+func RenderAnnuityResponseJSON(resp AnnuityResponseV1) ([]byte, error) {
+	return renderJSON(resp)
+}
+
+// RenderSinkingFundResponseJSON emits the sinking-fund response in the same
+// stable JSON form as RenderResponseJSON.
+func RenderSinkingFundResponseJSON(resp SinkingFundResponseV1) ([]byte, error) {
+	return renderJSON(resp)
+}
+
+// RenderGraduatedResponseJSON emits the graduated payment summary in the
+// same stable JSON form as RenderResponseJSON.
+func RenderGraduatedResponseJSON(resp GraduatedResponseV1) ([]byte, error) {
+	return renderJSON(resp)
+}
+
+// RenderNegAmResponseJSON emits the negative amortization summary in the
+// same stable JSON form as RenderResponseJSON.
+func RenderNegAmResponseJSON(resp NegAmResponseV1) ([]byte, error) {
+	return renderJSON(resp)
+}
+
+// RenderPayoffResponseJSON emits the payoff quote in the same stable JSON
+// form as RenderResponseJSON.
+func RenderPayoffResponseJSON(resp PayoffResponseV1) ([]byte, error) {
+	return renderJSON(resp)
+}
+
+// RenderPitiResponseJSON emits the PITI breakdown in the same stable JSON
+// form as RenderResponseJSON.
+func RenderPitiResponseJSON(resp PitiResponseV1) ([]byte, error) {
+	return renderJSON(resp)
+}
+
+// RenderPrecomputedResponseJSON emits the precomputed-interest rebate
+// quote in the same stable JSON form as RenderResponseJSON.
+func RenderPrecomputedResponseJSON(resp PrecomputedResponseV1) ([]byte, error) {
+	return renderJSON(resp)
+}
+
+// RenderRefinanceResponseJSON emits the refinance comparison in the same
+// stable JSON form as RenderResponseJSON.
+func RenderRefinanceResponseJSON(resp RefinanceResponseV1) ([]byte, error) {
+	return renderJSON(resp)
+}
+
+// RenderSimpleInterestResponseJSON emits the simple-interest ledger summary
+// in the same stable JSON form as RenderResponseJSON.
+func RenderSimpleInterestResponseJSON(resp SimpleInterestResponseV1) ([]byte, error) {
+	return renderJSON(resp)
+}
+
+func renderJSON(v any) ([]byte, error) {
+	b, err := json.MarshalIndent(v, "", "  ")
 	if err != nil {
 		return nil, err
 	}
@@ -20,21 +144,273 @@ func RenderResponseJSON(resp AmortizeResponseV1) ([]byte, error) {
 
 // RenderScheduleCSV emits a stable CSV schedule (LF line endings).
 func RenderScheduleCSV(rows []ScheduleRow) ([]byte, error) {
-	var buf bytes.Buffer
-	w := csv.NewWriter(&buf)
-	// csv.Writer uses \n internally; Go does not auto-convert line endings.
-	if err := w.Write([]string{"period", "date", "payment_cents", "principal_cents", "interest_cents", "balance_cents"}); err != nil {
-		return nil, err
+	recs := make([][]string, 0, len(rows))
+	for _, r := range rows {
+		recs = append(recs, []string{
+			itoa(r.Period),
+			r.Date,
+			itoa64(r.PaymentCents),
+			itoa64(r.PrincipalCents),
+			itoa64(r.InterestCents),
+			itoa64(r.BalanceCents),
+		})
 	}
+	return renderCSV([]string{"period", "date", "payment_cents", "principal_cents", "interest_cents", "balance_cents"}, recs)
+}
+
+// RenderArmScheduleCSV emits the ARM schedule: the amortization columns
+// plus rate_bps, the annual rate applied to each row's interest.
+func RenderArmScheduleCSV(rows []ArmScheduleRow) ([]byte, error) {
+	recs := make([][]string, 0, len(rows))
 	for _, r := range rows {
-		rec := []string{
+		recs = append(recs, []string{
 			itoa(r.Period),
 			r.Date,
+			itoa64(r.RateBps),
 			itoa64(r.PaymentCents),
 			itoa64(r.PrincipalCents),
 			itoa64(r.InterestCents),
 			itoa64(r.BalanceCents),
-		}
+		})
+	}
+	return renderCSV([]string{"period", "date", "rate_bps", "payment_cents", "principal_cents", "interest_cents", "balance_cents"}, recs)
+}
+
+// RenderBondCashFlowsCSV emits the bond's remaining cash flows from the
+// next coupon through maturity.
+func RenderBondCashFlowsCSV(rows []BondCashFlowRow) ([]byte, error) {
+	recs := make([][]string, 0, len(rows))
+	for _, r := range rows {
+		recs = append(recs, []string{
+			itoa(r.Period),
+			r.Date,
+			itoa64(r.CouponCents),
+			itoa64(r.PrincipalCents),
+			itoa64(r.CashFlowCents),
+		})
+	}
+	return renderCSV([]string{"period", "date", "coupon_cents", "principal_cents", "cash_flow_cents"}, recs)
+}
+
+// RenderCreditCardScheduleCSV emits every plan's billing cycles, plan by
+// plan: the payment, the cycle's average daily balance and the interest
+// charged at the statement.
+func RenderCreditCardScheduleCSV(rows []CreditCardRow) ([]byte, error) {
+	recs := make([][]string, 0, len(rows))
+	for _, r := range rows {
+		recs = append(recs, []string{
+			r.Plan,
+			itoa(r.Cycle),
+			r.PaymentDate,
+			r.StatementDate,
+			itoa64(r.PaymentCents),
+			itoa64(r.AverageDailyBalanceCents),
+			itoa64(r.InterestCents),
+			itoa64(r.BalanceCents),
+		})
+	}
+	return renderCSV([]string{"plan", "cycle", "payment_date", "statement_date", "payment_cents", "average_daily_balance_cents", "interest_cents", "balance_cents"}, recs)
+}
+
+// RenderDefermentScheduleCSV emits the deferment schedule: each month's
+// status, payment split, capitalized interest and the unpaid interest and
+// principal balance carried forward.
+func RenderDefermentScheduleCSV(rows []DefermentRow) ([]byte, error) {
+	recs := make([][]string, 0, len(rows))
+	for _, r := range rows {
+		recs = append(recs, []string{
+			itoa(r.Period),
+			r.Date,
+			r.Status,
+			itoa64(r.PaymentCents),
+			itoa64(r.InterestCents),
+			itoa64(r.InterestPaidCents),
+			itoa64(r.PrincipalCents),
+			itoa64(r.CapitalizedCents),
+			itoa64(r.UnpaidInterestCents),
+			itoa64(r.BalanceCents),
+		})
+	}
+	return renderCSV([]string{
+		"period", "date", "status", "payment_cents", "interest_cents", "interest_paid_cents",
+		"principal_cents", "capitalized_cents", "unpaid_interest_cents", "balance_cents",
+	}, recs)
+}
+
+// RenderDepreciationScheduleCSV emits one row per depreciation year;
+// book_value_cents is the value after that year.
+func RenderDepreciationScheduleCSV(rows []DepreciationRow) ([]byte, error) {
+	recs := make([][]string, 0, len(rows))
+	for _, r := range rows {
+		recs = append(recs, []string{
+			itoa(r.Period),
+			itoa(r.Year),
+			itoa64(r.DepreciationCents),
+			itoa64(r.AccumulatedDepreciationCents),
+			itoa64(r.BookValueCents),
+		})
+	}
+	return renderCSV([]string{"period", "year", "depreciation_cents", "accumulated_depreciation_cents", "book_value_cents"}, recs)
+}
+
+// RenderSavingsScheduleCSV emits the accumulation schedule shared by the
+// savings calculators.
+func RenderSavingsScheduleCSV(rows []SavingsRow) ([]byte, error) {
+	recs := make([][]string, 0, len(rows))
+	for _, r := range rows {
+		recs = append(recs, []string{
+			itoa(r.Period),
+			itoa64(r.ContributionCents),
+			itoa64(r.InterestCents),
+			itoa64(r.BalanceCents),
+		})
+	}
+	return renderCSV([]string{"period", "contribution_cents", "interest_cents", "balance_cents"}, recs)
+}
+
+// RenderGraduatedScheduleCSV emits the graduated schedule: the amortization
+// columns plus the payment step and whether the row amortized negatively
+// (principal_cents is then negative).
+func RenderGraduatedScheduleCSV(rows []GraduatedRow) ([]byte, error) {
+	recs := make([][]string, 0, len(rows))
+	for _, r := range rows {
+		recs = append(recs, []string{
+			itoa(r.Period),
+			r.Date,
+			itoa(r.Step),
+			itoa64(r.PaymentCents),
+			itoa64(r.PrincipalCents),
+			itoa64(r.InterestCents),
+			itoa64(r.BalanceCents),
+			strconv.FormatBool(r.NegativeAmortization),
+		})
+	}
+	return renderCSV([]string{"period", "date", "step", "payment_cents", "principal_cents", "interest_cents", "balance_cents", "negative_amortization"}, recs)
+}
+
+// RenderNegAmScheduleCSV emits the negative amortization schedule with a
+// signed principal_cents column, the deferred interest and the event that
+// set each row's payment.
+func RenderNegAmScheduleCSV(rows []NegAmRow) ([]byte, error) {
+	recs := make([][]string, 0, len(rows))
+	for _, r := range rows {
+		recs = append(recs, []string{
+			itoa(r.Period),
+			r.Date,
+			r.Event,
+			itoa64(r.PaymentCents),
+			itoa64(r.PrincipalCents),
+			itoa64(r.InterestCents),
+			itoa64(r.DeferredInterestCents),
+			itoa64(r.BalanceCents),
+		})
+	}
+	return renderCSV([]string{"period", "date", "event", "payment_cents", "principal_cents", "interest_cents", "deferred_interest_cents", "balance_cents"}, recs)
+}
+
+// RenderPitiScheduleCSV emits the amortization columns followed by the
+// escrow, PMI and HOA collected with each payment and the total payment.
+func RenderPitiScheduleCSV(rows []PitiRow) ([]byte, error) {
+	recs := make([][]string, 0, len(rows))
+	for _, r := range rows {
+		recs = append(recs, []string{
+			itoa(r.Period),
+			r.Date,
+			itoa64(r.PaymentCents),
+			itoa64(r.PrincipalCents),
+			itoa64(r.InterestCents),
+			itoa64(r.BalanceCents),
+			itoa64(r.PropertyTaxCents),
+			itoa64(r.InsuranceCents),
+			itoa64(r.PMICents),
+			itoa64(r.HOACents),
+			itoa64(r.TotalPaymentCents),
+		})
+	}
+	return renderCSV([]string{
+		"period", "date", "payment_cents", "principal_cents", "interest_cents", "balance_cents",
+		"property_tax_cents", "insurance_cents", "pmi_cents", "hoa_cents", "total_payment_cents",
+	}, recs)
+}
+
+// RenderPrecomputedScheduleCSV emits one row per contract payment: the
+// interest it earns under the request's method, the interest still
+// unearned and the payoff if the loan is paid off right after it.
+func RenderPrecomputedScheduleCSV(rows []PrecomputedRow) ([]byte, error) {
+	recs := make([][]string, 0, len(rows))
+	for _, r := range rows {
+		recs = append(recs, []string{
+			itoa(r.Period),
+			r.Date,
+			itoa64(r.PaymentCents),
+			itoa64(r.EarnedInterestCents),
+			itoa64(r.UnearnedInterestCents),
+			itoa64(r.RemainingPaymentsCents),
+			itoa64(r.PayoffCents),
+		})
+	}
+	return renderCSV([]string{"period", "date", "payment_cents", "earned_interest_cents", "unearned_interest_cents", "remaining_payments_cents", "payoff_cents"}, recs)
+}
+
+// RenderRefinanceScheduleCSV emits the side-by-side comparison: the
+// existing loan's remaining payments and the new loan's, month by month.
+func RenderRefinanceScheduleCSV(rows []RefinanceRow) ([]byte, error) {
+	recs := make([][]string, 0, len(rows))
+	for _, r := range rows {
+		recs = append(recs, []string{
+			itoa(r.Month),
+			r.ExistingDate,
+			itoa64(r.ExistingPaymentCents),
+			itoa64(r.ExistingInterestCents),
+			itoa64(r.ExistingBalanceCents),
+			r.NewDate,
+			itoa64(r.NewPaymentCents),
+			itoa64(r.NewInterestCents),
+			itoa64(r.NewBalanceCents),
+			itoa64(r.CumulativeSavingsCents),
+		})
+	}
+	return renderCSV([]string{
+		"month",
+		"existing_date", "existing_payment_cents", "existing_interest_cents", "existing_balance_cents",
+		"new_date", "new_payment_cents", "new_interest_cents", "new_balance_cents",
+		"cumulative_savings_cents",
+	}, recs)
+}
+
+// RenderSimpleInterestLedgerCSV emits one row per actual payment: the days
+// and interest accrued since the previous payment and how the payment was
+// applied.
+func RenderSimpleInterestLedgerCSV(rows []SimpleInterestRow) ([]byte, error) {
+	recs := make([][]string, 0, len(rows))
+	for _, r := range rows {
+		recs = append(recs, []string{
+			itoa(r.Payment),
+			r.Date,
+			itoa64(r.Days),
+			itoa64(r.PaymentCents),
+			itoa64(r.InterestAccruedCents),
+			itoa64(r.InterestPaidCents),
+			itoa64(r.PrincipalCents),
+			itoa64(r.UnpaidInterestCents),
+			itoa64(r.BalanceCents),
+		})
+	}
+	return renderCSV([]string{
+		"payment", "date", "days", "payment_cents", "interest_accrued_cents",
+		"interest_paid_cents", "principal_cents", "unpaid_interest_cents", "balance_cents",
+	}, recs)
+}
+
+// renderCSV writes header then records (LF line endings).
+func renderCSV(header []string, recs [][]string) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	// csv.Writer uses \n internally; Go does not auto-convert line endings.
+	if err := w.Write(header); err != nil {
+		return nil, err
+	}
+	for _, rec := range recs {
 		if err := w.Write(rec); err != nil {
 			return nil, err
 		}
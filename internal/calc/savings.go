@@ -0,0 +1,245 @@
+package calc
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	calcNameFutureValueV1 = "future_value"
+	calcNameAnnuityV1     = "annuity"
+	calcNameSinkingFundV1 = "sinking_fund"
+)
+
+// The savings calculators share one accumulation ledger. Each period:
+// - an annuity-due contribution is added first
+// - interest is balance * annual_rate_bps / (10000 * periods_per_year), rounded half-up to cents (as in AmortizeV1 at 30/360)
+// - an ordinary contribution is added last
+//
+// The future value is the last row's balance, so the schedule always ties
+// out: future value = initial balance + contributions + interest.
+
+// FutureValueV1 compounds a lump sum over term_months.
+func FutureValueV1(req FutureValueRequestV1) (FutureValueResponseV1, []SavingsRow, error) {
+	if req.PresentValueCents <= 0 {
+		return FutureValueResponseV1{}, nil, errors.New("present_value_cents must be > 0")
+	}
+	if req.PresentValueCents > MaxPrincipalCents {
+		return FutureValueResponseV1{}, nil, fmt.Errorf("present_value_cents must be <= %d", MaxPrincipalCents)
+	}
+	freq, n, err := validateSavingsTerms(req.AnnualRateBps, req.TermMonths, "compounding_frequency", req.CompoundingFrequency)
+	if err != nil {
+		return FutureValueResponseV1{}, nil, err
+	}
+	rows, err := savingsLedger(req.PresentValueCents, 0, req.AnnualRateBps, freq.perYear, n, false)
+	if err != nil {
+		return FutureValueResponseV1{}, nil, err
+	}
+	fv := rows[len(rows)-1].BalanceCents
+	return FutureValueResponseV1{
+		SchemaVersion:        schemaV1,
+		Calculator:           calcNameFutureValueV1,
+		PresentValueCents:    req.PresentValueCents,
+		AnnualRateBps:        req.AnnualRateBps,
+		TermMonths:           req.TermMonths,
+		CompoundingFrequency: frequencyName(req.CompoundingFrequency),
+		NumPeriods:           n,
+		FutureValueCents:     fv,
+		TotalInterestCents:   fv - req.PresentValueCents,
+	}, rows, nil
+}
+
+// AnnuityV1 accumulates a level contribution every period.
+func AnnuityV1(req AnnuityRequestV1) (AnnuityResponseV1, []SavingsRow, error) {
+	if req.ContributionCents <= 0 {
+		return AnnuityResponseV1{}, nil, errors.New("contribution_cents must be > 0")
+	}
+	if req.ContributionCents > MaxPrincipalCents {
+		return AnnuityResponseV1{}, nil, fmt.Errorf("contribution_cents must be <= %d", MaxPrincipalCents)
+	}
+	freq, n, due, err := validateSavingsReq(req.InitialBalanceCents, req.AnnualRateBps, req.TermMonths, req.PaymentFrequency, req.Timing)
+	if err != nil {
+		return AnnuityResponseV1{}, nil, err
+	}
+	rows, err := savingsLedger(req.InitialBalanceCents, req.ContributionCents, req.AnnualRateBps, freq.perYear, n, due)
+	if err != nil {
+		return AnnuityResponseV1{}, nil, err
+	}
+	contributions, interest, err := savingsTotals(rows)
+	if err != nil {
+		return AnnuityResponseV1{}, nil, err
+	}
+	return AnnuityResponseV1{
+		SchemaVersion:           schemaV1,
+		Calculator:              calcNameAnnuityV1,
+		ContributionCents:       req.ContributionCents,
+		InitialBalanceCents:     req.InitialBalanceCents,
+		AnnualRateBps:           req.AnnualRateBps,
+		TermMonths:              req.TermMonths,
+		PaymentFrequency:        frequencyName(req.PaymentFrequency),
+		Timing:                  timingName(req.Timing),
+		NumPeriods:              n,
+		FutureValueCents:        rows[len(rows)-1].BalanceCents,
+		TotalContributionsCents: contributions,
+		TotalInterestCents:      interest,
+	}, rows, nil
+}
+
+// SinkingFundV1 solves the smallest whole-cent contribution whose ledger
+// ends at or above target_cents. The future value never falls as the
+// contribution rises, so this is a binary search over cents, as in the
+// solvers; a contribution of target_cents always suffices. No contribution
+// is needed (payment_cents 0) when the initial balance alone gets there.
+func SinkingFundV1(req SinkingFundRequestV1) (SinkingFundResponseV1, []SavingsRow, error) {
+	if req.TargetCents <= 0 {
+		return SinkingFundResponseV1{}, nil, errors.New("target_cents must be > 0")
+	}
+	if req.TargetCents > MaxPrincipalCents {
+		return SinkingFundResponseV1{}, nil, fmt.Errorf("target_cents must be <= %d", MaxPrincipalCents)
+	}
+	freq, n, due, err := validateSavingsReq(req.InitialBalanceCents, req.AnnualRateBps, req.TermMonths, req.PaymentFrequency, req.Timing)
+	if err != nil {
+		return SinkingFundResponseV1{}, nil, err
+	}
+	payment, err := searchInt64(0, req.TargetCents, func(p int64) (bool, error) {
+		rows, err := savingsLedger(req.InitialBalanceCents, p, req.AnnualRateBps, freq.perYear, n, due)
+		if errors.Is(err, errOverflow) {
+			// The balance outgrew int64 cents, so it passed the target.
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		return rows[len(rows)-1].BalanceCents >= req.TargetCents, nil
+	})
+	if err != nil {
+		return SinkingFundResponseV1{}, nil, err
+	}
+	rows, err := savingsLedger(req.InitialBalanceCents, payment, req.AnnualRateBps, freq.perYear, n, due)
+	if err != nil {
+		return SinkingFundResponseV1{}, nil, err
+	}
+	fv := rows[len(rows)-1].BalanceCents
+	contributions, interest, err := savingsTotals(rows)
+	if err != nil {
+		return SinkingFundResponseV1{}, nil, err
+	}
+	return SinkingFundResponseV1{
+		SchemaVersion:           schemaV1,
+		Calculator:              calcNameSinkingFundV1,
+		TargetCents:             req.TargetCents,
+		InitialBalanceCents:     req.InitialBalanceCents,
+		AnnualRateBps:           req.AnnualRateBps,
+		TermMonths:              req.TermMonths,
+		PaymentFrequency:        frequencyName(req.PaymentFrequency),
+		Timing:                  timingName(req.Timing),
+		NumPeriods:              n,
+		PaymentCents:            payment,
+		FutureValueCents:        fv,
+		ExcessCents:             fv - req.TargetCents,
+		TotalContributionsCents: contributions,
+		TotalInterestCents:      interest,
+	}, rows, nil
+}
+
+// savingsLedger accumulates initial plus contribution per period for n
+// periods. Every addition is overflow-checked; a balance beyond int64
+// cents fails with errOverflow.
+func savingsLedger(initial, contribution, annualRateBps, perYear int64, n int, due bool) ([]SavingsRow, error) {
+	rows := make([]SavingsRow, 0, n)
+	bal := initial
+	var err error
+	for i := 1; i <= n; i++ {
+		if due {
+			if bal, err = addInt64(bal, contribution); err != nil {
+				return nil, err
+			}
+		}
+		interest, err := interestCents(bal, annualRateBps, perYear)
+		if err != nil {
+			return nil, err
+		}
+		if bal, err = addInt64(bal, interest); err != nil {
+			return nil, err
+		}
+		if !due {
+			if bal, err = addInt64(bal, contribution); err != nil {
+				return nil, err
+			}
+		}
+		rows = append(rows, SavingsRow{
+			Period:            i,
+			ContributionCents: contribution,
+			InterestCents:     interest,
+			BalanceCents:      bal,
+		})
+	}
+	return rows, nil
+}
+
+// savingsTotals sums the contributions and interest of a ledger.
+func savingsTotals(rows []SavingsRow) (contributions, interest int64, err error) {
+	for _, r := range rows {
+		if contributions, err = addInt64(contributions, r.ContributionCents); err != nil {
+			return 0, 0, err
+		}
+		if interest, err = addInt64(interest, r.InterestCents); err != nil {
+			return 0, 0, err
+		}
+	}
+	return contributions, interest, nil
+}
+
+func validateSavingsReq(initial, annualRateBps int64, termMonths int, frequency, timing string) (paymentFrequency, int, bool, error) {
+	if initial < 0 {
+		return paymentFrequency{}, 0, false, errors.New("initial_balance_cents must be >= 0")
+	}
+	if initial > MaxPrincipalCents {
+		return paymentFrequency{}, 0, false, fmt.Errorf("initial_balance_cents must be <= %d", MaxPrincipalCents)
+	}
+	if timing != "" && timing != TimingOrdinary && timing != TimingDue {
+		return paymentFrequency{}, 0, false, errors.New("timing must be one of ordinary, due")
+	}
+	freq, n, err := validateSavingsTerms(annualRateBps, termMonths, "payment_frequency", frequency)
+	return freq, n, timing == TimingDue, err
+}
+
+// validateSavingsTerms checks the rate and term shared by every savings
+// calculator; field names the frequency field in error messages.
+func validateSavingsTerms(annualRateBps int64, termMonths int, field, frequency string) (paymentFrequency, int, error) {
+	if annualRateBps < 0 {
+		return paymentFrequency{}, 0, errors.New("annual_rate_bps must be >= 0")
+	}
+	if annualRateBps > MaxAnnualRateBps {
+		return paymentFrequency{}, 0, fmt.Errorf("annual_rate_bps must be <= %d", MaxAnnualRateBps)
+	}
+	if termMonths <= 0 {
+		return paymentFrequency{}, 0, errors.New("term_months must be > 0")
+	}
+	if termMonths > MaxTermMonths {
+		return paymentFrequency{}, 0, fmt.Errorf("term_months must be <= %d", MaxTermMonths)
+	}
+	freq, ok := lookupFrequency(frequency)
+	if !ok {
+		return paymentFrequency{}, 0, fmt.Errorf("%s must be one of weekly, biweekly, semi_monthly, monthly, quarterly, semi_annual, annual", field)
+	}
+	n, ok := freq.payments(termMonths)
+	if !ok {
+		return paymentFrequency{}, 0, fmt.Errorf("term_months must be a multiple of %d for %s periods", freq.termMultiple(), frequency)
+	}
+	return freq, n, nil
+}
+
+func frequencyName(name string) string {
+	if name == "" {
+		return FrequencyMonthly
+	}
+	return name
+}
+
+func timingName(name string) string {
+	if name == "" {
+		return TimingOrdinary
+	}
+	return name
+}
@@ -0,0 +1,115 @@
+package calc
+
+// Contribution timings accepted by the annuity and sinking-fund
+// calculators.
+const (
+	TimingOrdinary = "ordinary" // contributions at the end of each period
+	TimingDue      = "due"      // contributions at the start of each period
+)
+
+// FutureValueRequestV1 is the input contract for the v1 lump-sum future
+// value calculator.
+//
+// Rates follow AmortizeRequestV1: AnnualRateBps is a nominal annual rate
+// compounded CompoundingFrequency times a year (any payment_frequency;
+// monthly by default) over TermMonths.
+type FutureValueRequestV1 struct {
+	PresentValueCents    int64  `json:"present_value_cents"`
+	AnnualRateBps        int64  `json:"annual_rate_bps"`
+	TermMonths           int    `json:"term_months"`
+	CompoundingFrequency string `json:"compounding_frequency,omitempty"`
+}
+
+// FutureValueResponseV1 is the versioned JSON response for the v1 lump-sum
+// future value calculator.
+type FutureValueResponseV1 struct {
+	SchemaVersion        string `json:"schema_version"`
+	Calculator           string `json:"calculator"`
+	PresentValueCents    int64  `json:"present_value_cents"`
+	AnnualRateBps        int64  `json:"annual_rate_bps"`
+	TermMonths           int    `json:"term_months"`
+	CompoundingFrequency string `json:"compounding_frequency"`
+	NumPeriods           int    `json:"num_periods"`
+	FutureValueCents     int64  `json:"future_value_cents"`
+	TotalInterestCents   int64  `json:"total_interest_cents"`
+}
+
+// AnnuityRequestV1 is the input contract for the v1 annuity future value
+// calculator: a level ContributionCents every period, at the end
+// (ordinary, the default) or the start (due) of the period, on top of an
+// optional InitialBalanceCents. Interest compounds at the payment
+// frequency.
+type AnnuityRequestV1 struct {
+	ContributionCents   int64  `json:"contribution_cents"`
+	InitialBalanceCents int64  `json:"initial_balance_cents,omitempty"`
+	AnnualRateBps       int64  `json:"annual_rate_bps"`
+	TermMonths          int    `json:"term_months"`
+	PaymentFrequency    string `json:"payment_frequency,omitempty"`
+	Timing              string `json:"timing,omitempty"`
+}
+
+// AnnuityResponseV1 is the versioned JSON response for the v1 annuity
+// calculator.
+//
+// Notes:
+// - future_value_cents = initial_balance_cents + total_contributions_cents + total_interest_cents
+type AnnuityResponseV1 struct {
+	SchemaVersion           string `json:"schema_version"`
+	Calculator              string `json:"calculator"`
+	ContributionCents       int64  `json:"contribution_cents"`
+	InitialBalanceCents     int64  `json:"initial_balance_cents"`
+	AnnualRateBps           int64  `json:"annual_rate_bps"`
+	TermMonths              int    `json:"term_months"`
+	PaymentFrequency        string `json:"payment_frequency"`
+	Timing                  string `json:"timing"`
+	NumPeriods              int    `json:"num_periods"`
+	FutureValueCents        int64  `json:"future_value_cents"`
+	TotalContributionsCents int64  `json:"total_contributions_cents"`
+	TotalInterestCents      int64  `json:"total_interest_cents"`
+}
+
+// SinkingFundRequestV1 is the input contract for the v1 sinking-fund
+// calculator: it solves the level contribution that grows
+// InitialBalanceCents to at least TargetCents. Timing and frequency are as
+// in AnnuityRequestV1.
+type SinkingFundRequestV1 struct {
+	TargetCents         int64  `json:"target_cents"`
+	InitialBalanceCents int64  `json:"initial_balance_cents,omitempty"`
+	AnnualRateBps       int64  `json:"annual_rate_bps"`
+	TermMonths          int    `json:"term_months"`
+	PaymentFrequency    string `json:"payment_frequency,omitempty"`
+	Timing              string `json:"timing,omitempty"`
+}
+
+// SinkingFundResponseV1 is the versioned JSON response for the v1
+// sinking-fund calculator.
+//
+// Notes:
+// - payment_cents is the smallest whole-cent contribution whose schedule reaches target_cents
+// - excess_cents = future_value_cents - target_cents (always >= 0)
+type SinkingFundResponseV1 struct {
+	SchemaVersion           string `json:"schema_version"`
+	Calculator              string `json:"calculator"`
+	TargetCents             int64  `json:"target_cents"`
+	InitialBalanceCents     int64  `json:"initial_balance_cents"`
+	AnnualRateBps           int64  `json:"annual_rate_bps"`
+	TermMonths              int    `json:"term_months"`
+	PaymentFrequency        string `json:"payment_frequency"`
+	Timing                  string `json:"timing"`
+	NumPeriods              int    `json:"num_periods"`
+	PaymentCents            int64  `json:"payment_cents"`
+	FutureValueCents        int64  `json:"future_value_cents"`
+	ExcessCents             int64  `json:"excess_cents"`
+	TotalContributionsCents int64  `json:"total_contributions_cents"`
+	TotalInterestCents      int64  `json:"total_interest_cents"`
+}
+
+// SavingsRow is one period of an accumulation schedule. BalanceCents is
+// the balance at the end of the period, after its contribution and
+// interest.
+type SavingsRow struct {
+	Period            int
+	ContributionCents int64
+	InterestCents     int64
+	BalanceCents      int64
+}
@@ -0,0 +1,164 @@
+package calc
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const calcNameSimpleInterestV1 = "simple_interest"
+
+// MaxSimpleInterestPayments bounds the payments in one Simple interest v1
+// request: one a week over the longest loan term (MaxTermMonths).
+const MaxSimpleInterestPayments = MaxTermMonths / 12 * 53
+
+// SimpleInterestV1 builds the ledger of a daily simple-interest loan from
+// its actual payment history.
+//
+// Each payment accrues interest on the principal balance for the days since
+// the previous payment (or start_date), exactly as the odd first period of
+// Amortize v1 accrues (30/360 counts days360), rounded half-up once per
+// payment. The payment pays unpaid interest first and the rest reduces
+// principal. Interest is never charged on unpaid interest. A payment larger
+// than the payoff amount on its date fails, so the ledger never goes
+// negative; a payment on the same day as the previous one accrues nothing.
+func SimpleInterestV1(req SimpleInterestRequestV1) (SimpleInterestResponseV1, []SimpleInterestRow, error) {
+	start, dates, asOf, err := validateSimpleInterestReq(req)
+	if err != nil {
+		return SimpleInterestResponseV1{}, nil, err
+	}
+	dc := req.DayCount
+	if dc == "" {
+		dc = DayCountActual365
+	}
+
+	resp := SimpleInterestResponseV1{
+		SchemaVersion: schemaV1,
+		Calculator:    calcNameSimpleInterestV1,
+		DayCount:      dc,
+		StartDate:     req.StartDate,
+		AsOfDate:      asOf.Format("2006-01-02"),
+		NumPayments:   len(req.Payments),
+	}
+
+	rows := make([]SimpleInterestRow, 0, len(req.Payments))
+	balance, unpaid, last := req.PrincipalCents, int64(0), start
+	for i, p := range req.Payments {
+		accrued, err := oddInterestCents(balance, req.AnnualRateBps, dc, last, dates[i])
+		if err != nil {
+			return SimpleInterestResponseV1{}, nil, err
+		}
+		due, err := addInt64(unpaid, accrued)
+		if err != nil {
+			return SimpleInterestResponseV1{}, nil, err
+		}
+		payoff, err := addInt64(balance, due)
+		if err != nil {
+			return SimpleInterestResponseV1{}, nil, err
+		}
+		if p.AmountCents > payoff {
+			return SimpleInterestResponseV1{}, nil, fmt.Errorf("payments[%d].amount_cents %d exceeds the payoff amount %d on %s", i, p.AmountCents, payoff, p.Date)
+		}
+
+		row := SimpleInterestRow{
+			Payment:              i + 1,
+			Date:                 p.Date,
+			Days:                 oddDays(dc, last, dates[i]),
+			PaymentCents:         p.AmountCents,
+			InterestAccruedCents: accrued,
+			InterestPaidCents:    min(p.AmountCents, due),
+		}
+		row.PrincipalCents = p.AmountCents - row.InterestPaidCents
+		row.UnpaidInterestCents = due - row.InterestPaidCents
+		row.BalanceCents = balance - row.PrincipalCents
+		rows = append(rows, row)
+
+		if resp.TotalInterestPaidCents, err = addInt64(resp.TotalInterestPaidCents, row.InterestPaidCents); err != nil {
+			return SimpleInterestResponseV1{}, nil, err
+		}
+		if resp.TotalPrincipalPaidCents, err = addInt64(resp.TotalPrincipalPaidCents, row.PrincipalCents); err != nil {
+			return SimpleInterestResponseV1{}, nil, err
+		}
+		balance, unpaid, last = row.BalanceCents, row.UnpaidInterestCents, dates[i]
+	}
+	if resp.TotalPaidCents, err = addInt64(resp.TotalInterestPaidCents, resp.TotalPrincipalPaidCents); err != nil {
+		return SimpleInterestResponseV1{}, nil, err
+	}
+
+	resp.PrincipalBalanceCents = balance
+	resp.UnpaidInterestCents = unpaid
+	resp.AccruedDays = oddDays(dc, last, asOf)
+	if resp.AccruedInterestCents, err = oddInterestCents(balance, req.AnnualRateBps, dc, last, asOf); err != nil {
+		return SimpleInterestResponseV1{}, nil, err
+	}
+	if resp.PerDiemCents, err = perDiemCents(balance, req.AnnualRateBps, dc, asOf); err != nil {
+		return SimpleInterestResponseV1{}, nil, err
+	}
+	owed, err := addInt64(balance, unpaid)
+	if err != nil {
+		return SimpleInterestResponseV1{}, nil, err
+	}
+	if resp.PayoffAmountCents, err = addInt64(owed, resp.AccruedInterestCents); err != nil {
+		return SimpleInterestResponseV1{}, nil, err
+	}
+	resp.PaidOff = resp.PayoffAmountCents == 0
+	return resp, rows, nil
+}
+
+// validateSimpleInterestReq checks the request and returns the parsed start
+// date, payment dates and as-of date.
+func validateSimpleInterestReq(req SimpleInterestRequestV1) (time.Time, []time.Time, time.Time, error) {
+	var zero time.Time
+	if req.PrincipalCents <= 0 {
+		return zero, nil, zero, errors.New("principal_cents must be > 0")
+	}
+	if req.PrincipalCents > MaxPrincipalCents {
+		return zero, nil, zero, fmt.Errorf("principal_cents must be <= %d", MaxPrincipalCents)
+	}
+	if req.AnnualRateBps < 0 {
+		return zero, nil, zero, errors.New("annual_rate_bps must be >= 0")
+	}
+	if req.AnnualRateBps > MaxAnnualRateBps {
+		return zero, nil, zero, fmt.Errorf("annual_rate_bps must be <= %d", MaxAnnualRateBps)
+	}
+	if !validDayCount(req.DayCount) {
+		return zero, nil, zero, errors.New("day_count must be one of 30/360, actual/365, actual/360, actual/actual")
+	}
+	start, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		return zero, nil, zero, fmt.Errorf("start_date must be YYYY-MM-DD: %w", err)
+	}
+
+	if len(req.Payments) > MaxSimpleInterestPayments {
+		return zero, nil, zero, fmt.Errorf("payments must have at most %d entries", MaxSimpleInterestPayments)
+	}
+	last := start
+	dates := make([]time.Time, len(req.Payments))
+	for i, p := range req.Payments {
+		d, err := time.Parse("2006-01-02", p.Date)
+		if err != nil {
+			return zero, nil, zero, fmt.Errorf("payments[%d].date must be YYYY-MM-DD: %w", i, err)
+		}
+		if d.Before(last) {
+			return zero, nil, zero, fmt.Errorf("payments[%d].date must be on or after start_date and the previous payment", i)
+		}
+		if p.AmountCents <= 0 {
+			return zero, nil, zero, fmt.Errorf("payments[%d].amount_cents must be > 0", i)
+		}
+		if p.AmountCents > MaxPrincipalCents {
+			return zero, nil, zero, fmt.Errorf("payments[%d].amount_cents must be <= %d", i, MaxPrincipalCents)
+		}
+		dates[i], last = d, d
+	}
+
+	asOf := last
+	if req.AsOfDate != "" {
+		if asOf, err = time.Parse("2006-01-02", req.AsOfDate); err != nil {
+			return zero, nil, zero, fmt.Errorf("as_of_date must be YYYY-MM-DD: %w", err)
+		}
+		if asOf.Before(last) {
+			return zero, nil, zero, errors.New("as_of_date must be on or after start_date and the last payment")
+		}
+	}
+	return start, dates, asOf, nil
+}
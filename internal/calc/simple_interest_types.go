@@ -0,0 +1,70 @@
+package calc
+
+// SimpleInterestRequestV1 is the input contract for the v1 daily
+// simple-interest ledger.
+//
+// Interest accrues daily on the unpaid principal from StartDate (the
+// funding date) under DayCount; empty means actual/365, the usual basis
+// for simple-interest auto loans. Payments are the borrower's actual
+// payments in date order, each applied to accrued interest first and then
+// to principal. AsOfDate, when set, is the date the closing payoff figures
+// are accrued to; it defaults to the last payment date.
+type SimpleInterestRequestV1 struct {
+	PrincipalCents int64                     `json:"principal_cents"`
+	AnnualRateBps  int64                     `json:"annual_rate_bps"`
+	StartDate      string                    `json:"start_date"`
+	DayCount       string                    `json:"day_count,omitempty"`
+	Payments       []SimpleInterestPaymentV1 `json:"payments"`
+	AsOfDate       string                    `json:"as_of_date,omitempty"`
+}
+
+// SimpleInterestPaymentV1 is one dated payment received.
+type SimpleInterestPaymentV1 struct {
+	Date        string `json:"date"`
+	AmountCents int64  `json:"amount_cents"`
+}
+
+// SimpleInterestResponseV1 is the versioned JSON response for the v1
+// daily simple-interest ledger.
+//
+// Notes:
+// - principal_balance_cents and unpaid_interest_cents are the balances after the last payment
+// - accrued_interest_cents is interest from the last payment (or start_date) to as_of_date
+// - payoff_amount_cents = principal_balance_cents + unpaid_interest_cents + accrued_interest_cents
+// - total_paid_cents = total_interest_paid_cents + total_principal_paid_cents
+type SimpleInterestResponseV1 struct {
+	SchemaVersion string `json:"schema_version"`
+	Calculator    string `json:"calculator"`
+	DayCount      string `json:"day_count"`
+	StartDate     string `json:"start_date"`
+	AsOfDate      string `json:"as_of_date"`
+	NumPayments   int    `json:"num_payments"`
+
+	TotalPaidCents          int64 `json:"total_paid_cents"`
+	TotalInterestPaidCents  int64 `json:"total_interest_paid_cents"`
+	TotalPrincipalPaidCents int64 `json:"total_principal_paid_cents"`
+
+	PrincipalBalanceCents int64 `json:"principal_balance_cents"`
+	UnpaidInterestCents   int64 `json:"unpaid_interest_cents"`
+	AccruedDays           int64 `json:"accrued_days"`
+	AccruedInterestCents  int64 `json:"accrued_interest_cents"`
+	PerDiemCents          int64 `json:"per_diem_cents"`
+	PayoffAmountCents     int64 `json:"payoff_amount_cents"`
+	PaidOff               bool  `json:"paid_off"`
+}
+
+// SimpleInterestRow is one payment in the ledger. Days and
+// InterestAccruedCents cover the period since the previous payment (or
+// start_date); UnpaidInterestCents is accrued interest the payment did not
+// cover, carried forward without compounding.
+type SimpleInterestRow struct {
+	Payment              int
+	Date                 string
+	Days                 int64
+	PaymentCents         int64
+	InterestAccruedCents int64
+	InterestPaidCents    int64
+	PrincipalCents       int64
+	UnpaidInterestCents  int64
+	BalanceCents         int64
+}
@@ -0,0 +1,213 @@
+package calc
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+const (
+	calcNameSolveTermV1      = "solve_term"
+	calcNameSolveRateV1      = "solve_rate"
+	calcNameSolvePrincipalV1 = "solve_principal"
+)
+
+// The solvers invert scheduledPaymentCents over monthly, fixed-rate loans.
+// The level payment is monotone in each input (non-increasing in term,
+// non-decreasing in rate and principal, even after rounding), so each
+// solver is a binary search over whole units: months, bps or cents.
+//
+// Every solver returns the schedule of the solved loan, computed by
+// AmortizeV1, so the result can be replayed through /v1/amortize.
+
+// SolveTermV1 returns the shortest term_months whose level payment is at most
+// payment_cents. The solved loan's payment may be below the target.
+func SolveTermV1(req SolveTermRequestV1) (SolveResponseV1, []ScheduleRow, error) {
+	areq := AmortizeRequestV1{
+		PrincipalCents: req.PrincipalCents,
+		AnnualRateBps:  req.AnnualRateBps,
+		TermMonths:     1,
+		StartDate:      req.StartDate,
+	}
+	if err := validateSolveReq(areq, req.PaymentCents); err != nil {
+		return SolveResponseV1{}, nil, err
+	}
+
+	pmt := func(n int) (int64, error) {
+		return scheduledPaymentCents(req.PrincipalCents, req.AnnualRateBps, n, monthsPerYr)
+	}
+	minPmt, err := pmt(MaxTermMonths)
+	if err != nil {
+		return SolveResponseV1{}, nil, err
+	}
+	if minPmt > req.PaymentCents {
+		return SolveResponseV1{}, nil, fmt.Errorf("payment_cents must be >= %d to repay principal within %d months", minPmt, MaxTermMonths)
+	}
+	n, err := searchInt(1, MaxTermMonths, func(n int) (bool, error) {
+		p, err := pmt(n)
+		return p <= req.PaymentCents, err
+	})
+	if err != nil {
+		return SolveResponseV1{}, nil, err
+	}
+
+	areq.TermMonths = n
+	return solvedSchedule(calcNameSolveTermV1, "term_months", areq, req.PaymentCents)
+}
+
+// SolveRateV1 returns the whole-bps annual_rate_bps whose level payment is
+// closest to payment_cents. When several rates are equally close (either
+// side of the target, or a run of rates rounding to the same payment), the
+// lowest rate wins.
+func SolveRateV1(req SolveRateRequestV1) (SolveResponseV1, []ScheduleRow, error) {
+	areq := AmortizeRequestV1{
+		PrincipalCents: req.PrincipalCents,
+		TermMonths:     req.TermMonths,
+		StartDate:      req.StartDate,
+	}
+	if err := validateSolveReq(areq, req.PaymentCents); err != nil {
+		return SolveResponseV1{}, nil, err
+	}
+
+	pmt := func(bps int64) (int64, error) {
+		return scheduledPaymentCents(req.PrincipalCents, bps, req.TermMonths, monthsPerYr)
+	}
+	lowPmt, err := pmt(0)
+	if err != nil {
+		return SolveResponseV1{}, nil, err
+	}
+	highPmt, err := pmt(MaxAnnualRateBps)
+	if err != nil {
+		return SolveResponseV1{}, nil, err
+	}
+	if req.PaymentCents < lowPmt || req.PaymentCents > highPmt {
+		return SolveResponseV1{}, nil, fmt.Errorf("payment_cents must be between %d and %d for rates 0 to %d bps", lowPmt, highPmt, MaxAnnualRateBps)
+	}
+
+	// lowestRateFor returns the lowest rate whose payment is >= p.
+	lowestRateFor := func(p int64) (int64, error) {
+		r, err := searchInt(0, int(MaxAnnualRateBps), func(bps int) (bool, error) {
+			got, err := pmt(int64(bps))
+			return got >= p, err
+		})
+		return int64(r), err
+	}
+	rate, err := lowestRateFor(req.PaymentCents)
+	if err != nil {
+		return SolveResponseV1{}, nil, err
+	}
+	above, err := pmt(rate)
+	if err != nil {
+		return SolveResponseV1{}, nil, err
+	}
+	if above != req.PaymentCents {
+		// rate > 0 here: pmt(0) <= target < pmt(rate).
+		below, err := pmt(rate - 1)
+		if err != nil {
+			return SolveResponseV1{}, nil, err
+		}
+		if req.PaymentCents-below <= above-req.PaymentCents {
+			if rate, err = lowestRateFor(below); err != nil {
+				return SolveResponseV1{}, nil, err
+			}
+		}
+	}
+
+	areq.AnnualRateBps = rate
+	return solvedSchedule(calcNameSolveRateV1, "annual_rate_bps", areq, req.PaymentCents)
+}
+
+// SolvePrincipalV1 returns the largest principal_cents whose level payment
+// is at most payment_cents.
+func SolvePrincipalV1(req SolvePrincipalRequestV1) (SolveResponseV1, []ScheduleRow, error) {
+	areq := AmortizeRequestV1{
+		PrincipalCents: 1,
+		AnnualRateBps:  req.AnnualRateBps,
+		TermMonths:     req.TermMonths,
+		StartDate:      req.StartDate,
+	}
+	if err := validateSolveReq(areq, req.PaymentCents); err != nil {
+		return SolveResponseV1{}, nil, err
+	}
+
+	pmt := func(p int64) (int64, error) {
+		return scheduledPaymentCents(p, req.AnnualRateBps, req.TermMonths, monthsPerYr)
+	}
+	minPmt, err := pmt(1)
+	if err != nil {
+		return SolveResponseV1{}, nil, err
+	}
+	maxPmt, err := pmt(MaxPrincipalCents)
+	if err != nil {
+		return SolveResponseV1{}, nil, err
+	}
+	if req.PaymentCents < minPmt || req.PaymentCents > maxPmt {
+		return SolveResponseV1{}, nil, fmt.Errorf("payment_cents must be between %d and %d for principals 1 to %d cents", minPmt, maxPmt, MaxPrincipalCents)
+	}
+	// One less than the smallest principal whose payment exceeds the target;
+	// the search yields MaxPrincipalCents+1 when none does.
+	over, err := searchInt64(1, MaxPrincipalCents+1, func(p int64) (bool, error) {
+		got, err := pmt(p)
+		return got > req.PaymentCents, err
+	})
+	if err != nil {
+		return SolveResponseV1{}, nil, err
+	}
+
+	areq.PrincipalCents = over - 1
+	return solvedSchedule(calcNameSolvePrincipalV1, "principal_cents", areq, req.PaymentCents)
+}
+
+// validateSolveReq checks the known amortization inputs (the solved field
+// holds a valid placeholder) and the target payment.
+func validateSolveReq(areq AmortizeRequestV1, paymentCents int64) error {
+	if err := validateReq(areq); err != nil {
+		return err
+	}
+	if paymentCents <= 0 {
+		return errors.New("payment_cents must be > 0")
+	}
+	return nil
+}
+
+// solvedSchedule amortizes the solved request and wraps the result.
+func solvedSchedule(name, solvedFor string, areq AmortizeRequestV1, target int64) (SolveResponseV1, []ScheduleRow, error) {
+	aresp, rows, err := AmortizeV1(areq)
+	if err != nil {
+		return SolveResponseV1{}, nil, err
+	}
+	resp := SolveResponseV1{
+		SchemaVersion:      schemaV1,
+		Calculator:         name,
+		SolvedFor:          solvedFor,
+		TargetPaymentCents: target,
+		PaymentCents:       aresp.PaymentCents,
+		PaymentDiffCents:   aresp.PaymentCents - target,
+		Amortization:       aresp,
+	}
+	return resp, rows, nil
+}
+
+// searchInt returns the smallest i in [lo, hi) for which ok(i) is true, or
+// hi if there is none. ok must be monotone (false...true) and is never
+// called with hi. The first error from ok stops the search.
+func searchInt(lo, hi int, ok func(int) (bool, error)) (int, error) {
+	v, err := searchInt64(int64(lo), int64(hi), func(i int64) (bool, error) { return ok(int(i)) })
+	return int(v), err
+}
+
+func searchInt64(lo, hi int64, ok func(int64) (bool, error)) (int64, error) {
+	var firstErr error
+	n := sort.Search(int(hi-lo), func(k int) bool {
+		if firstErr != nil {
+			return true
+		}
+		got, err := ok(lo + int64(k))
+		if err != nil {
+			firstErr = err
+			return true
+		}
+		return got
+	})
+	return lo + int64(n), firstErr
+}
@@ -0,0 +1,44 @@
+package calc
+
+// SolveTermRequestV1 asks for the shortest monthly term whose level payment
+// does not exceed PaymentCents.
+type SolveTermRequestV1 struct {
+	PrincipalCents int64  `json:"principal_cents"`
+	AnnualRateBps  int64  `json:"annual_rate_bps"`
+	PaymentCents   int64  `json:"payment_cents"`
+	StartDate      string `json:"start_date"`
+}
+
+// SolveRateRequestV1 asks for the whole-bps annual rate whose level payment
+// is closest to PaymentCents. Ties go to the lowest rate.
+type SolveRateRequestV1 struct {
+	PrincipalCents int64  `json:"principal_cents"`
+	TermMonths     int    `json:"term_months"`
+	PaymentCents   int64  `json:"payment_cents"`
+	StartDate      string `json:"start_date"`
+}
+
+// SolvePrincipalRequestV1 asks for the largest principal whose level payment
+// does not exceed PaymentCents.
+type SolvePrincipalRequestV1 struct {
+	AnnualRateBps int64  `json:"annual_rate_bps"`
+	TermMonths    int    `json:"term_months"`
+	PaymentCents  int64  `json:"payment_cents"`
+	StartDate     string `json:"start_date"`
+}
+
+// SolveResponseV1 is the versioned JSON response shared by the v1 solvers.
+//
+// Notes:
+// - solved_for names the amortization input that was solved
+// - payment_cents is the solved loan's level payment; payment_diff_cents = payment_cents - target_payment_cents
+// - amortization is exactly the /v1/amortize response for the solved inputs
+type SolveResponseV1 struct {
+	SchemaVersion      string             `json:"schema_version"`
+	Calculator         string             `json:"calculator"`
+	SolvedFor          string             `json:"solved_for"`
+	TargetPaymentCents int64              `json:"target_payment_cents"`
+	PaymentCents       int64              `json:"payment_cents"`
+	PaymentDiffCents   int64              `json:"payment_diff_cents"`
+	Amortization       AmortizeResponseV1 `json:"amortization"`
+}
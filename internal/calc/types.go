@@ -6,34 +6,133 @@ package calc
 // Rate is expressed in basis points (bps), where 100 bps = 1.00%.
 // StartDate is ISO-8601 (YYYY-MM-DD) and is used only for schedule dates.
 //
+// InterestOnlyMonths, when set, is a leading window of interest-only
+// payments (principal zero); the level payment then amortizes the balance
+// over the remaining payments. It must be shorter than TermMonths.
+//
+// AmortizationMonths, when set, is the (longer) period the payment is
+// computed over; the loan still matures after TermMonths and the final row
+// pays the remaining balance as a balloon. Zero means fully amortizing.
+//
+// FundingDate and FirstPaymentDate describe a loan that funds on one date
+// and takes its first payment later. They are set together and replace
+// StartDate. Interest on the odd days of the first period is handled per
+// OddInterest (see the OddInterest* constants; default add to first payment).
+//
+// PaymentFrequency selects how often payments fall due (see the Frequency*
+// constants). Empty means monthly. TermMonths must hold a whole number of
+// payment periods.
+//
+// DateRoll selects how schedule dates are adjusted for month ends and
+// business days (see the DateRoll* constants). Empty means none.
+//
+// DayCount selects how interest accrues between schedule dates (see the
+// DayCount* constants). Empty means 30/360, the original monthly accrual.
+//
+// Prepayments are optional. ExtraPrincipalCents is paid on top of every
+// scheduled payment; Prepayments are dated lump sums applied with the first
+// scheduled payment on or after their date. Neither recasts the payment.
+//
+// Each field is bounded (see MaxPrincipalCents, MaxAnnualRateBps and
+// MaxTermMonths) so the calculator never overflows int64 cents.
+//
 // This contract is intentionally small and strict.
 // If a field is invalid, the calculator returns a stable, user-facing error.
 type AmortizeRequestV1 struct {
-	PrincipalCents int64  `json:"principal_cents"`
-	AnnualRateBps  int64  `json:"annual_rate_bps"`
-	TermMonths     int    `json:"term_months"`
-	StartDate      string `json:"start_date"`
+	PrincipalCents     int64  `json:"principal_cents"`
+	AnnualRateBps      int64  `json:"annual_rate_bps"`
+	TermMonths         int    `json:"term_months"`
+	AmortizationMonths int    `json:"amortization_months,omitempty"`
+	InterestOnlyMonths int    `json:"interest_only_months,omitempty"`
+	StartDate          string `json:"start_date,omitempty"`
+	FundingDate        string `json:"funding_date,omitempty"`
+	FirstPaymentDate   string `json:"first_payment_date,omitempty"`
+	OddInterest        string `json:"odd_interest,omitempty"`
+	PaymentFrequency   string `json:"payment_frequency,omitempty"`
+	DateRoll           string `json:"date_roll,omitempty"`
+	DayCount           string `json:"day_count,omitempty"`
+
+	ExtraPrincipalCents int64          `json:"extra_principal_cents,omitempty"`
+	Prepayments         []PrepaymentV1 `json:"prepayments,omitempty"`
+}
+
+// PrepaymentV1 is a dated lump-sum principal prepayment.
+type PrepaymentV1 struct {
+	Date        string `json:"date"`
+	AmountCents int64  `json:"amount_cents"`
 }
 
 // AmortizeResponseV1 is the versioned JSON response for the v1 amortization calculator.
 //
 // Notes:
-// - payment_cents is the scheduled payment (most periods)
-// - last_payment_cents may differ by 1 cent due to final payoff rounding
+// - payment_cents is the scheduled payment (most periods); with interest_only_months it is the payment after the interest-only window
+// - last_payment_cents absorbs rounding residue so the balance ends at zero
 // - totals are deterministic and derived from the computed schedule
+// - start_date is the first payment date (first_payment_date when set)
+// - num_payments is present only when the request sets payment_frequency
+// - odd_period is present only when the request sets funding_date
+// - amortization_months and balloon_payment_cents are present only for balloon loans
+// - balloon_payment_cents is the final payment: remaining balance plus that period's interest; absent when prepayments pay the loan off before the final row
+// - prepayment is present only when the request carries prepayments
 //
 // JSON is emitted from a struct (not a map) so key ordering is stable.
 type AmortizeResponseV1 struct {
-	SchemaVersion      string `json:"schema_version"`
-	Calculator         string `json:"calculator"`
-	PrincipalCents     int64  `json:"principal_cents"`
-	AnnualRateBps      int64  `json:"annual_rate_bps"`
-	TermMonths         int    `json:"term_months"`
-	StartDate          string `json:"start_date"`
-	PaymentCents       int64  `json:"payment_cents"`
-	LastPaymentCents   int64  `json:"last_payment_cents"`
-	TotalInterestCents int64  `json:"total_interest_cents"`
-	TotalPaidCents     int64  `json:"total_paid_cents"`
+	SchemaVersion       string `json:"schema_version"`
+	Calculator          string `json:"calculator"`
+	PrincipalCents      int64  `json:"principal_cents"`
+	AnnualRateBps       int64  `json:"annual_rate_bps"`
+	TermMonths          int    `json:"term_months"`
+	AmortizationMonths  int    `json:"amortization_months,omitempty"`
+	InterestOnlyMonths  int    `json:"interest_only_months,omitempty"`
+	StartDate           string `json:"start_date"`
+	PaymentFrequency    string `json:"payment_frequency,omitempty"`
+	NumPayments         int    `json:"num_payments,omitempty"`
+	DateRoll            string `json:"date_roll,omitempty"`
+	DayCount            string `json:"day_count,omitempty"`
+	PaymentCents        int64  `json:"payment_cents"`
+	LastPaymentCents    int64  `json:"last_payment_cents"`
+	BalloonPaymentCents int64  `json:"balloon_payment_cents,omitempty"`
+	TotalInterestCents  int64  `json:"total_interest_cents"`
+	TotalPaidCents      int64  `json:"total_paid_cents"`
+
+	OddPeriod  *OddPeriodV1         `json:"odd_period,omitempty"`
+	Prepayment *PrepaymentSummaryV1 `json:"prepayment,omitempty"`
+}
+
+// OddPeriodV1 reports the irregular first period of a loan that funds before
+// its regular first accrual period starts (or after it, for a short period).
+//
+// odd_days is counted on the request's day-count basis (days360 for 30/360)
+// and is negative for a short first period. odd_interest_cents is added to
+// the first payment's interest, or to the amortized balance when treatment
+// is capitalize.
+type OddPeriodV1 struct {
+	FundingDate      string `json:"funding_date"`
+	FirstPaymentDate string `json:"first_payment_date"`
+	OddDays          int64  `json:"odd_days"`
+	OddInterestCents int64  `json:"odd_interest_cents"`
+	Treatment        string `json:"treatment"`
+}
+
+// PrepaymentSummaryV1 reports the effect of prepayments against the
+// contractual schedule (same loan, no prepayments).
+//
+// payoff_months and months_saved are present only for monthly schedules.
+// payoff_payments and payments_saved count payments at the schedule's
+// frequency and, like num_payments, are present only when the request sets
+// payment_frequency.
+//
+// total_prepaid_cents counts only extra principal actually applied; amounts
+// that would overpay the balance are not charged.
+type PrepaymentSummaryV1 struct {
+	ExtraPrincipalCents      int64 `json:"extra_principal_cents"`
+	TotalPrepaidCents        int64 `json:"total_prepaid_cents"`
+	PayoffMonths             *int  `json:"payoff_months,omitempty"`
+	MonthsSaved              *int  `json:"months_saved,omitempty"`
+	PayoffPayments           *int  `json:"payoff_payments,omitempty"`
+	PaymentsSaved            *int  `json:"payments_saved,omitempty"`
+	ContractualInterestCents int64 `json:"contractual_interest_cents"`
+	InterestSavedCents       int64 `json:"interest_saved_cents"`
 }
 
 // ScheduleRow is one amortization schedule row.
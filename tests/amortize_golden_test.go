@@ -1,125 +1,79 @@
 package tests
 
 import (
-	"bytes"
-	"encoding/json"
-	"os"
-	"path/filepath"
-	"sort"
 	"testing"
 
 	"github.com/nicholaskarlson/proof-first-finance-calc/internal/calc"
 )
 
 func TestAmortizeV1_Goldens(t *testing.T) {
-	root := filepath.Join("..", "fixtures")
-	inRoot := filepath.Join(root, "input")
-
-	entries, err := os.ReadDir(inRoot)
-	if err != nil {
-		t.Fatalf("read fixtures input: %v", err)
-	}
-
-	caseNames := make([]string, 0, len(entries))
-	for _, e := range entries {
-		if e.IsDir() {
-			caseNames = append(caseNames, e.Name())
-		}
-	}
-	sort.Strings(caseNames)
-
-	for _, c := range caseNames {
-		c := c
-		t.Run(c, func(t *testing.T) {
-			inPath := filepath.Join(inRoot, c, "request.json")
-			inB, err := os.ReadFile(inPath)
-			if err != nil {
-				t.Fatalf("read input request: %v", err)
-			}
-
-			var req calc.AmortizeRequestV1
-			if err := json.Unmarshal(inB, &req); err != nil {
-				t.Fatalf("unmarshal request: %v", err)
-			}
-
-			expDir := filepath.Join(root, "expected", c)
-			errPath := filepath.Join(expDir, "error.txt")
-			if _, statErr := os.Stat(errPath); statErr == nil {
-				// Expected-fail case: compare stable one-line error body.
-				_, _, err := calc.AmortizeV1(req)
-				if err == nil {
-					t.Fatalf("expected error, got nil")
-				}
-				got := []byte("error: " + err.Error() + "\n")
-				want, err := os.ReadFile(errPath)
-				if err != nil {
-					t.Fatalf("read expected error: %v", err)
-				}
-				if !bytes.Equal(got, want) {
-					t.Fatalf("error.txt mismatch\n--- got ---\n%s\n--- want ---\n%s", string(got), string(want))
-				}
-				return
-			}
-
-			wantRespPath := filepath.Join(expDir, "response.json")
-			wantCSVPath := filepath.Join(expDir, "schedule.csv")
-
-			resp, rows, err := calc.AmortizeV1(req)
-			if err != nil {
-				t.Fatalf("AmortizeV1: %v", err)
-			}
-
-			// Invariants (proof-first): totals must tie out.
-			assertScheduleInvariants(t, req, resp, rows)
-
-			gotResp, err := calc.RenderResponseJSON(resp)
-			if err != nil {
-				t.Fatalf("render response json: %v", err)
-			}
-			wantResp, err := os.ReadFile(wantRespPath)
-			if err != nil {
-				t.Fatalf("read expected response: %v", err)
-			}
-			if !bytes.Equal(gotResp, wantResp) {
-				t.Fatalf("response.json mismatch\n--- got ---\n%s\n--- want ---\n%s", string(gotResp), string(wantResp))
-			}
-
-			gotCSV, err := calc.RenderScheduleCSV(rows)
-			if err != nil {
-				t.Fatalf("render schedule csv: %v", err)
-			}
-			wantCSV, err := os.ReadFile(wantCSVPath)
-			if err != nil {
-				t.Fatalf("read expected schedule: %v", err)
-			}
-			if !bytes.Equal(gotCSV, wantCSV) {
-				t.Fatalf("schedule.csv mismatch\n--- got ---\n%s\n--- want ---\n%s", string(gotCSV), string(wantCSV))
-			}
-		})
-	}
+	runCalendarGoldens(t, "", calc.AmortizeV1WithCalendar, calc.RenderResponseJSON, calc.RenderScheduleCSV, func(t *testing.T, req calc.AmortizeRequestV1, _ *calc.HolidayCalendar, resp calc.AmortizeResponseV1, rows []calc.ScheduleRow) {
+		// Invariants (proof-first): totals must tie out.
+		assertScheduleInvariants(t, req, resp, rows)
+	})
 }
 
 func assertScheduleInvariants(t *testing.T, req calc.AmortizeRequestV1, resp calc.AmortizeResponseV1, rows []calc.ScheduleRow) {
 	t.Helper()
-	if len(rows) != req.TermMonths {
-		t.Fatalf("expected %d rows, got %d", req.TermMonths, len(rows))
+	scheduled := req.TermMonths
+	if resp.NumPayments != 0 {
+		scheduled = resp.NumPayments
+	}
+	wantRows := scheduled
+	if p := resp.Prepayment; p != nil {
+		monthly := req.PaymentFrequency == "" || req.PaymentFrequency == calc.FrequencyMonthly
+		if (p.PayoffMonths != nil) != monthly || (p.MonthsSaved != nil) != monthly {
+			t.Fatalf("payoff_months/months_saved must be present exactly for monthly schedules")
+		}
+		if (p.PayoffPayments != nil) != (req.PaymentFrequency != "") || (p.PaymentsSaved != nil) != (req.PaymentFrequency != "") {
+			t.Fatalf("payoff_payments/payments_saved must be present exactly when payment_frequency is set")
+		}
+		payoff, saved := p.PayoffMonths, p.MonthsSaved
+		if payoff == nil {
+			payoff, saved = p.PayoffPayments, p.PaymentsSaved
+		} else if p.PayoffPayments != nil && (*p.PayoffPayments != *payoff || *p.PaymentsSaved != *saved) {
+			t.Fatalf("monthly payment counts %d/%d != month counts %d/%d", *p.PayoffPayments, *p.PaymentsSaved, *payoff, *saved)
+		}
+		wantRows = *payoff
+		if *saved != scheduled-wantRows {
+			t.Fatalf("payments saved mismatch: %d != scheduled %d - payoff %d", *saved, scheduled, wantRows)
+		}
+		if resp.Prepayment.InterestSavedCents != resp.Prepayment.ContractualInterestCents-resp.TotalInterestCents {
+			t.Fatalf("interest saved mismatch: %d != contractual %d - total %d", resp.Prepayment.InterestSavedCents, resp.Prepayment.ContractualInterestCents, resp.TotalInterestCents)
+		}
+	}
+	if len(rows) != wantRows {
+		t.Fatalf("expected %d rows, got %d", wantRows, len(rows))
 	}
 	if rows[len(rows)-1].BalanceCents != 0 {
 		t.Fatalf("final balance must be 0, got %d", rows[len(rows)-1].BalanceCents)
 	}
+	// Capitalized odd-period interest is amortized like principal.
+	wantPrincipal := req.PrincipalCents
+	if resp.OddPeriod != nil && resp.OddPeriod.Treatment == calc.OddInterestCapitalize {
+		wantPrincipal += resp.OddPeriod.OddInterestCents
+	}
 	var sumPrincipal, sumInterest, sumPaid int64
-	prevBal := req.PrincipalCents
+	prevBal := wantPrincipal
 	for _, r := range rows {
 		sumPrincipal += r.PrincipalCents
 		sumInterest += r.InterestCents
 		sumPaid += r.PaymentCents
-		if r.BalanceCents > prevBal {
-			t.Fatalf("balance must be non-increasing, saw %d -> %d", prevBal, r.BalanceCents)
+		if r.PaymentCents != r.PrincipalCents+r.InterestCents {
+			t.Fatalf("period %d: payment %d != principal %d + interest %d", r.Period, r.PaymentCents, r.PrincipalCents, r.InterestCents)
+		}
+		if r.BalanceCents != prevBal-r.PrincipalCents {
+			t.Fatalf("period %d: balance %d != previous %d - principal %d", r.Period, r.BalanceCents, prevBal, r.PrincipalCents)
+		}
+		// Only an actual-day period longer than the level payment covers
+		// amortizes negatively.
+		if r.PrincipalCents < 0 && (req.DayCount == "" || req.DayCount == calc.DayCount30360) {
+			t.Fatalf("period %d: negative principal %d under 30/360", r.Period, r.PrincipalCents)
 		}
 		prevBal = r.BalanceCents
 	}
-	if sumPrincipal != req.PrincipalCents {
-		t.Fatalf("principal tie-out failed: sum principal %d != principal %d", sumPrincipal, req.PrincipalCents)
+	if sumPrincipal != wantPrincipal {
+		t.Fatalf("principal tie-out failed: sum principal %d != principal %d", sumPrincipal, wantPrincipal)
 	}
 	if sumInterest != resp.TotalInterestCents {
 		t.Fatalf("interest tie-out failed: sum interest %d != resp.total_interest_cents %d", sumInterest, resp.TotalInterestCents)
@@ -130,4 +84,16 @@ func assertScheduleInvariants(t *testing.T, req calc.AmortizeRequestV1, resp cal
 	if rows[len(rows)-1].PaymentCents != resp.LastPaymentCents {
 		t.Fatalf("last payment mismatch: schedule %d != resp.last_payment_cents %d", rows[len(rows)-1].PaymentCents, resp.LastPaymentCents)
 	}
+
+	// A balloon is the final contractual payment; a loan prepaid off
+	// before it has none.
+	if req.AmortizationMonths != 0 {
+		want := resp.LastPaymentCents
+		if p := resp.Prepayment; p != nil && (p.MonthsSaved != nil && *p.MonthsSaved > 0 || p.PaymentsSaved != nil && *p.PaymentsSaved > 0) {
+			want = 0
+		}
+		if resp.BalloonPaymentCents != want {
+			t.Fatalf("balloon_payment_cents %d, want %d", resp.BalloonPaymentCents, want)
+		}
+	}
 }
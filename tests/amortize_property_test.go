@@ -0,0 +1,113 @@
+package tests
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/nicholaskarlson/proof-first-finance-calc/internal/calc"
+)
+
+// TestAmortizeV1_Properties runs generated loans through the same invariants
+// as the goldens. The generator is seeded, so every run checks the same cases.
+func TestAmortizeV1_Properties(t *testing.T) {
+	n := 5000
+	if testing.Short() {
+		n = 500
+	}
+	rng := rand.New(rand.NewPCG(20260101, 4))
+
+	for i := 0; i < n; i++ {
+		req := calc.AmortizeRequestV1{
+			PrincipalCents: genPrincipalCents(rng),
+			AnnualRateBps:  genRateBps(rng),
+			TermMonths:     1 + rng.IntN(480),
+			StartDate:      "2026-01-31",
+		}
+		resp, rows, err := calc.AmortizeV1(req)
+		if err != nil {
+			t.Fatalf("case %d %+v: %v", i, req, err)
+		}
+		for _, r := range rows {
+			if r.PrincipalCents < 0 || r.InterestCents < 0 || r.BalanceCents < 0 {
+				t.Fatalf("case %d %+v: negative money in period %d: %+v", i, req, r.Period, r)
+			}
+		}
+		assertScheduleInvariants(t, req, resp, rows)
+	}
+}
+
+// TestAmortizeV1_ActualDayCountProperties checks that under the actual
+// day counts every loan schedules, that only a level payment short of a long
+// period's interest amortizes negatively, and that the final row pays at
+// most the level payment, short of it by less than one cent's worth of the
+// schedule's compounding.
+func TestAmortizeV1_ActualDayCountProperties(t *testing.T) {
+	n := 1000
+	if testing.Short() {
+		n = 100
+	}
+	rng := rand.New(rand.NewPCG(20260201, 5))
+	dayCounts := []string{calc.DayCountActual365, calc.DayCountActual360, calc.DayCountActualActual}
+	starts := []string{"2026-01-31", "2026-02-28", "2027-06-15", "2028-02-29"}
+
+	for i := 0; i < n; i++ {
+		req := calc.AmortizeRequestV1{
+			PrincipalCents: genPrincipalCents(rng),
+			AnnualRateBps:  genRateBps(rng),
+			TermMonths:     1 + rng.IntN(480),
+			StartDate:      starts[rng.IntN(len(starts))],
+			DayCount:       dayCounts[rng.IntN(len(dayCounts))],
+		}
+		resp, rows, err := calc.AmortizeV1(req)
+		if err != nil {
+			t.Fatalf("case %d %+v: %v", i, req, err)
+		}
+		for _, r := range rows {
+			if r.InterestCents < 0 || r.BalanceCents < 0 || r.PaymentCents < 0 {
+				t.Fatalf("case %d %+v: negative money in period %d: %+v", i, req, r.Period, r)
+			}
+			// Only a level payment short of its period's interest
+			// amortizes negatively.
+			if r.PrincipalCents < 0 && r.PaymentCents != resp.PaymentCents {
+				t.Fatalf("case %d %+v: negative principal in period %d without the level payment: %+v", i, req, r.Period, r)
+			}
+		}
+		assertScheduleInvariants(t, req, resp, rows)
+
+		// One cent less per payment leaves the final balance short by the
+		// sum of every period's growth (longest period: 31 days over 360),
+		// plus up to half a cent of interest rounding per period.
+		growth := 1 + float64(req.AnnualRateBps)/10000*31/360
+		var slack float64
+		for k := 0; k < req.TermMonths; k++ {
+			slack += 1.5 * math.Pow(growth, float64(k))
+		}
+		if short := resp.PaymentCents - resp.LastPaymentCents; short < 0 || float64(short) > slack+1 {
+			t.Fatalf("case %d %+v: last payment %d vs level %d (slack %.0f)", i, req, resp.LastPaymentCents, resp.PaymentCents, slack)
+		}
+	}
+}
+
+// genPrincipalCents spreads principals across magnitudes (1 cent to $10M)
+// so rounding residue shows up at every scale.
+func genPrincipalCents(rng *rand.Rand) int64 {
+	digits := 1 + rng.IntN(9)
+	var max int64 = 1
+	for d := 0; d < digits; d++ {
+		max *= 10
+	}
+	return 1 + rng.Int64N(max)
+}
+
+// genRateBps favors realistic rates but includes zero and high rates.
+func genRateBps(rng *rand.Rand) int64 {
+	switch rng.IntN(10) {
+	case 0:
+		return 0
+	case 1:
+		return rng.Int64N(10000)
+	default:
+		return rng.Int64N(1500)
+	}
+}
@@ -0,0 +1,127 @@
+package tests
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nicholaskarlson/proof-first-finance-calc/internal/calc"
+)
+
+func TestAprV1_Goldens(t *testing.T) {
+	runCalendarGoldens(t, "apr", calc.AprV1WithCalendar, calc.RenderAprResponseJSON, calc.RenderScheduleCSV, func(t *testing.T, _ calc.AprRequestV1, _ *calc.HolidayCalendar, resp calc.AprResponseV1, rows []calc.ScheduleRow) {
+		assertAprInvariants(t, resp, rows)
+	})
+}
+
+// TestAprV1_RegZAppendixJ reproduces the published actuarial-method
+// examples of Regulation Z, Appendix J(c)(1): each fixture's amount
+// financed, level payment, payment count, odd period and APR are the
+// published ones. apr01 reaches $5,000 financed through a prepaid fee on a
+// 6% note; the others use a 0% note whose whole finance charge is prepaid,
+// so every payment is exactly the published one. Example (c)(1)(v), 30
+// weekly payments, is not a whole number of months and so has no
+// AmortizeRequestV1 form.
+func TestAprV1_RegZAppendixJ(t *testing.T) {
+	examples := []struct {
+		name         string
+		financed     int64
+		payment      int64
+		n            int
+		t            int
+		oddDays      int64
+		publishedBps int64
+	}{
+		{"apr01_regz_appj_monthly_regular", 500000, 23000, 24, 1, 0, 969},
+		{"apr03_regz_appj_monthly_long_first_period", 600000, 20000, 36, 1, 19, 1182},
+		{"apr08_regz_appj_semi_monthly_short_first_period", 500000, 21917, 24, 0, 6, 1034},
+		{"apr09_regz_appj_quarterly_long_first_period", 1000000, 38500, 40, 1, 39, 897},
+	}
+	for _, ex := range examples {
+		ex := ex
+		t.Run(ex.name, func(t *testing.T) {
+			inB, err := os.ReadFile(filepath.Join("..", "fixtures", "apr", "input", ex.name, "request.json"))
+			if err != nil {
+				t.Fatalf("read input request: %v", err)
+			}
+			var req calc.AprRequestV1
+			if err := json.Unmarshal(inB, &req); err != nil {
+				t.Fatalf("unmarshal request: %v", err)
+			}
+			resp, rows, err := calc.AprV1(req)
+			if err != nil {
+				t.Fatalf("AprV1: %v", err)
+			}
+			if resp.AmountFinancedCents != ex.financed || len(rows) != ex.n {
+				t.Fatalf("amount financed %d over %d payments, want %d over %d", resp.AmountFinancedCents, len(rows), ex.financed, ex.n)
+			}
+			for _, r := range rows {
+				if r.PaymentCents != ex.payment {
+					t.Fatalf("period %d: payment %d, want %d", r.Period, r.PaymentCents, ex.payment)
+				}
+			}
+			if resp.OddUnitPeriods != ex.t || resp.OddDays != ex.oddDays {
+				t.Fatalf("odd period t=%d, %d days; want t=%d, %d days", resp.OddUnitPeriods, resp.OddDays, ex.t, ex.oddDays)
+			}
+			if resp.AprBps != ex.publishedBps {
+				t.Fatalf("apr %d bps, want published %d", resp.AprBps, ex.publishedBps)
+			}
+		})
+	}
+}
+
+func assertAprInvariants(t *testing.T, resp calc.AprResponseV1, rows []calc.ScheduleRow) {
+	t.Helper()
+	var charges, paid int64
+	for _, c := range resp.PrepaidFinanceCharges {
+		charges += c.AmountCents
+	}
+	for _, r := range rows {
+		paid += r.PaymentCents
+	}
+	if charges != resp.PrepaidFinanceChargesCents {
+		t.Fatalf("prepaid charges %d != sum %d", resp.PrepaidFinanceChargesCents, charges)
+	}
+	if resp.AmountFinancedCents != resp.PrincipalCents-charges {
+		t.Fatalf("amount financed %d != principal %d - charges %d", resp.AmountFinancedCents, resp.PrincipalCents, charges)
+	}
+	if paid != resp.TotalOfPaymentsCents || paid != resp.Amortization.TotalPaidCents {
+		t.Fatalf("total of payments %d != schedule %d", resp.TotalOfPaymentsCents, paid)
+	}
+	if resp.FinanceChargeCents != paid-resp.AmountFinancedCents {
+		t.Fatalf("finance charge %d != payments %d - financed %d", resp.FinanceChargeCents, paid, resp.AmountFinancedCents)
+	}
+	if resp.NumPayments != len(rows) {
+		t.Fatalf("num payments %d != %d rows", resp.NumPayments, len(rows))
+	}
+
+	// Independent float64 solve of the Appendix J equation.
+	f := float64(resp.OddDays) / float64(resp.UnitPeriodDays)
+	pv := func(apr float64) float64 {
+		i := apr / float64(resp.UnitPeriodsPerYear)
+		var sum float64
+		for k, r := range rows {
+			sum += float64(r.PaymentCents) / ((1 + f*i) * math.Pow(1+i, float64(resp.OddUnitPeriods+k)))
+		}
+		return sum
+	}
+	lo, hi := 0.0, 100.0
+	for n := 0; n < 200; n++ {
+		mid := (lo + hi) / 2
+		if pv(mid) >= float64(resp.AmountFinancedCents) {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	bps := lo * 10000
+	if frac := bps - math.Floor(bps); math.Abs(frac-0.5) < 1e-6 {
+		t.Logf("float APR %.8f bps sits on a rounding boundary; skipping cross-check", bps)
+		return
+	}
+	if want := int64(math.Floor(bps + 0.5)); want != resp.AprBps {
+		t.Fatalf("apr %d bps, float cross-check %.6f bps rounds to %d", resp.AprBps, bps, want)
+	}
+}
@@ -0,0 +1,79 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/nicholaskarlson/proof-first-finance-calc/internal/calc"
+)
+
+func TestArmV1_Goldens(t *testing.T) {
+	runCalendarGoldens(t, "arm", calc.ArmV1WithCalendar, calc.RenderArmResponseJSON, calc.RenderArmScheduleCSV, func(t *testing.T, req calc.ArmRequestV1, _ *calc.HolidayCalendar, resp calc.ArmResponseV1, rows []calc.ArmScheduleRow) {
+		assertArmInvariants(t, req, resp, rows)
+	})
+}
+
+func assertArmInvariants(t *testing.T, req calc.ArmRequestV1, resp calc.ArmResponseV1, rows []calc.ArmScheduleRow) {
+	t.Helper()
+	if len(rows) != req.TermMonths {
+		t.Fatalf("expected %d rows, got %d", req.TermMonths, len(rows))
+	}
+	if rows[len(rows)-1].BalanceCents != 0 {
+		t.Fatalf("final balance must be 0, got %d", rows[len(rows)-1].BalanceCents)
+	}
+
+	// Every rate move happens at a listed reset and respects the caps.
+	ceiling := req.InitialRateBps + req.LifetimeCapBps
+	resetAt := make(map[int]calc.ArmResetV1, len(resp.Resets))
+	prevRate := req.InitialRateBps
+	for k, rs := range resp.Resets {
+		resetAt[rs.Period] = rs
+		changeCap := req.PeriodicCapBps
+		if k == 0 {
+			changeCap = req.InitialCapBps
+		}
+		if d := rs.RateBps - prevRate; d > changeCap || d < -changeCap {
+			t.Fatalf("reset %d: rate moved %d bps, cap %d", rs.Period, d, changeCap)
+		}
+		if rs.RateBps > ceiling || rs.RateBps < req.FloorBps {
+			t.Fatalf("reset %d: rate %d outside [%d, %d]", rs.Period, rs.RateBps, req.FloorBps, ceiling)
+		}
+		prevRate = rs.RateBps
+	}
+
+	var sumPrincipal, sumInterest, sumPaid int64
+	rate := req.InitialRateBps
+	prevBal := req.PrincipalCents
+	for _, r := range rows {
+		if rs, ok := resetAt[r.Period]; ok {
+			rate = rs.RateBps
+		}
+		if r.RateBps != rate {
+			t.Fatalf("period %d: rate %d, want %d", r.Period, r.RateBps, rate)
+		}
+		if r.PaymentCents != r.PrincipalCents+r.InterestCents {
+			t.Fatalf("period %d: payment %d != principal %d + interest %d", r.Period, r.PaymentCents, r.PrincipalCents, r.InterestCents)
+		}
+		if r.BalanceCents > prevBal {
+			t.Fatalf("balance must be non-increasing, saw %d -> %d", prevBal, r.BalanceCents)
+		}
+		prevBal = r.BalanceCents
+		sumPrincipal += r.PrincipalCents
+		sumInterest += r.InterestCents
+		sumPaid += r.PaymentCents
+	}
+	if sumPrincipal != req.PrincipalCents {
+		t.Fatalf("principal tie-out failed: sum principal %d != principal %d", sumPrincipal, req.PrincipalCents)
+	}
+	if sumInterest != resp.TotalInterestCents {
+		t.Fatalf("interest tie-out failed: sum interest %d != resp.total_interest_cents %d", sumInterest, resp.TotalInterestCents)
+	}
+	if sumPaid != resp.TotalPaidCents {
+		t.Fatalf("paid tie-out failed: sum paid %d != resp.total_paid_cents %d", sumPaid, resp.TotalPaidCents)
+	}
+	if rows[0].PaymentCents != resp.InitialPaymentCents {
+		t.Fatalf("initial payment mismatch: schedule %d != resp.initial_payment_cents %d", rows[0].PaymentCents, resp.InitialPaymentCents)
+	}
+	if rows[len(rows)-1].PaymentCents != resp.LastPaymentCents {
+		t.Fatalf("last payment mismatch: schedule %d != resp.last_payment_cents %d", rows[len(rows)-1].PaymentCents, resp.LastPaymentCents)
+	}
+}
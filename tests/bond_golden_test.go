@@ -0,0 +1,138 @@
+package tests
+
+import (
+	"math"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/nicholaskarlson/proof-first-finance-calc/internal/calc"
+)
+
+func TestBondPriceV1_Goldens(t *testing.T) {
+	runGoldens(t, "bond_price", calc.BondPriceV1, calc.RenderBondResponseJSON, calc.RenderBondCashFlowsCSV, func(t *testing.T, req calc.BondPriceRequestV1, resp calc.BondResponseV1, rows []calc.BondCashFlowRow) {
+		price := assertBondInvariants(t, resp, rows)
+		if resp.YieldBps != req.YieldBps {
+			t.Fatalf("yield_bps %d, requested %d", resp.YieldBps, req.YieldBps)
+		}
+		if want := price(float64(req.YieldBps)); math.Abs(want-float64(resp.DirtyPriceCents)) > 0.5+1e-6 {
+			t.Fatalf("dirty price %d cents, float cross-check %.4f", resp.DirtyPriceCents, want)
+		}
+	})
+}
+
+func TestBondYieldV1_Goldens(t *testing.T) {
+	runGoldens(t, "bond_yield", calc.BondYieldV1, calc.RenderBondResponseJSON, calc.RenderBondCashFlowsCSV, func(t *testing.T, req calc.BondYieldRequestV1, resp calc.BondResponseV1, rows []calc.BondCashFlowRow) {
+		price := assertBondInvariants(t, resp, rows)
+		if resp.CleanPriceCents != req.CleanPriceCents {
+			t.Fatalf("clean price %d, requested %d", resp.CleanPriceCents, req.CleanPriceCents)
+		}
+		// yield_bps rounds the exact yield, so the target dirty price lies
+		// between the prices half a bp either side.
+		target := float64(resp.DirtyPriceCents)
+		if hi, lo := price(float64(resp.YieldBps)-0.5), price(float64(resp.YieldBps)+0.5); target > hi+1e-6 || target < lo-1e-6 {
+			t.Fatalf("dirty price %d outside [%.4f, %.4f] around yield %d bps", resp.DirtyPriceCents, lo, hi, resp.YieldBps)
+		}
+	})
+}
+
+// assertBondInvariants checks the cash flows and analytics of resp and
+// returns an independent float64 dirty pricer in annual yield bps. The
+// durations and convexity must match finite differences of the float price.
+func assertBondInvariants(t *testing.T, resp calc.BondResponseV1, rows []calc.BondCashFlowRow) func(float64) float64 {
+	t.Helper()
+	if resp.DirtyPriceCents != resp.CleanPriceCents+resp.AccruedInterestCents {
+		t.Fatalf("dirty %d != clean %d + accrued %d", resp.DirtyPriceCents, resp.CleanPriceCents, resp.AccruedInterestCents)
+	}
+	if len(rows) != resp.CouponsRemaining || rows[0].Date != resp.NextCouponDate || rows[len(rows)-1].Date != resp.MaturityDate {
+		t.Fatalf("cash flows do not run from next_coupon_date to maturity_date")
+	}
+	for i, r := range rows {
+		if r.CouponCents != resp.CouponCents || r.CashFlowCents != r.CouponCents+r.PrincipalCents {
+			t.Fatalf("row %d: coupon %d + principal %d != cash flow %d", r.Period, r.CouponCents, r.PrincipalCents, r.CashFlowCents)
+		}
+		if want := int64(0); i == len(rows)-1 {
+			want = resp.FaceCents
+			if r.PrincipalCents != want {
+				t.Fatalf("final principal %d != face %d", r.PrincipalCents, want)
+			}
+		} else if r.PrincipalCents != want {
+			t.Fatalf("row %d repays principal before maturity", r.Period)
+		}
+	}
+
+	f := map[string]float64{"annual": 1, "semi_annual": 2, "quarterly": 4, "monthly": 12}[resp.CouponFrequency]
+	prev := mustDate(t, resp.PreviousCouponDate)
+	next := mustDate(t, resp.NextCouponDate)
+	settle := mustDate(t, resp.SettlementDate)
+	days := func(a, b time.Time) float64 { return b.Sub(a).Hours() / 24 }
+	var accrued, w float64
+	switch resp.DayCount {
+	case "30/360":
+		accrued, w = float64(resp.AccruedDays)*f/360, days360f(settle, next)*f/360
+	case "actual/360":
+		accrued, w = days(prev, settle)*f/360, days(settle, next)*f/360
+	case "actual/365":
+		accrued, w = days(prev, settle)*f/365, days(settle, next)*f/365
+	default:
+		accrued, w = days(prev, settle)/days(prev, next), days(settle, next)/days(prev, next)
+	}
+	if want := math.Floor(float64(resp.CouponCents)*accrued + 0.5); float64(resp.AccruedInterestCents) != want {
+		t.Fatalf("accrued %d cents, float cross-check %.0f", resp.AccruedInterestCents, want)
+	}
+
+	price := func(bps float64) float64 {
+		i := bps / 10000 / f
+		var s float64
+		for k, r := range rows {
+			s += float64(r.CashFlowCents) / math.Pow(1+i, float64(k))
+		}
+		return s / (1 + w*i)
+	}
+	const h = 1.0 // bps
+	y := float64(resp.YieldBps)
+	p, up, down := price(y), price(y+h), price(y-h)
+	dy := h / 10000
+	modified := -(up - down) / (2 * dy) / p
+	convexity := (up - 2*p + down) / (dy * dy) / p
+	macaulay := modified * (1 + y/10000/f)
+	for _, m := range []struct {
+		name string
+		got  string
+		want float64
+	}{
+		{"modified_duration_years", resp.ModifiedDurationYears, modified},
+		{"macaulay_duration_years", resp.MacaulayDurationYears, macaulay},
+		{"convexity", resp.Convexity, convexity},
+	} {
+		got, err := strconv.ParseFloat(m.got, 64)
+		if err != nil {
+			t.Fatalf("%s: %v", m.name, err)
+		}
+		if math.Abs(got-m.want) > 1e-3*math.Max(1, math.Abs(m.want)) {
+			t.Fatalf("%s %s, float cross-check %.6f", m.name, m.got, m.want)
+		}
+	}
+	return price
+}
+
+func mustDate(t *testing.T, s string) time.Time {
+	t.Helper()
+	d, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		t.Fatalf("parse date %q: %v", s, err)
+	}
+	return d
+}
+
+// days360f is the 30/360 day count used for coupon accrual.
+func days360f(a, b time.Time) float64 {
+	d1, d2 := a.Day(), b.Day()
+	if d1 == 31 {
+		d1 = 30
+	}
+	if d2 == 31 && d1 == 30 {
+		d2 = 30
+	}
+	return float64(360*(b.Year()-a.Year()) + 30*(int(b.Month())-int(a.Month())) + d2 - d1)
+}
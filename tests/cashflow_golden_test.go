@@ -0,0 +1,76 @@
+package tests
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/nicholaskarlson/proof-first-finance-calc/internal/calc"
+)
+
+func TestNpvV1_Goldens(t *testing.T) {
+	runGoldens(t, "npv", withoutRows(calc.NpvV1), calc.RenderNpvResponseJSON, nil, func(t *testing.T, req calc.NpvRequestV1, resp calc.NpvResponseV1, _ []struct{}) {
+		var sum float64
+		for k, c := range req.CashFlowsCents {
+			sum += float64(c) / math.Pow(1+float64(req.RateBps)/10000, float64(k))
+		}
+		if math.Abs(sum-float64(resp.NpvCents)) > 0.5+1e-6 {
+			t.Fatalf("npv %d cents, float cross-check %.4f", resp.NpvCents, sum)
+		}
+		if resp.NetCashFlowCents != resp.TotalInCents-resp.TotalOutCents {
+			t.Fatalf("net %d != in %d - out %d", resp.NetCashFlowCents, resp.TotalInCents, resp.TotalOutCents)
+		}
+	})
+}
+
+func TestIrrV1_Goldens(t *testing.T) {
+	runGoldens(t, "irr", withoutRows(calc.IrrV1), calc.RenderIrrResponseJSON, nil, func(t *testing.T, req calc.IrrRequestV1, resp calc.IrrResponseV1, _ []struct{}) {
+		// The root lies within half a bp of irr_bps, so the NPV must not
+		// keep its sign from irr_bps - 1 to irr_bps + 1.
+		below, err := calc.NpvV1(calc.NpvRequestV1{RateBps: resp.IrrBps - 1, CashFlowsCents: req.CashFlowsCents})
+		if err != nil {
+			t.Fatalf("NpvV1: %v", err)
+		}
+		above, err := calc.NpvV1(calc.NpvRequestV1{RateBps: resp.IrrBps + 1, CashFlowsCents: req.CashFlowsCents})
+		if err != nil {
+			t.Fatalf("NpvV1: %v", err)
+		}
+		if below.NpvCents > 0 && above.NpvCents > 0 || below.NpvCents < 0 && above.NpvCents < 0 {
+			t.Fatalf("npv keeps its sign around irr %d bps: %d, %d", resp.IrrBps, below.NpvCents, above.NpvCents)
+		}
+	})
+}
+
+func TestXirrV1_Goldens(t *testing.T) {
+	runGoldens(t, "xirr", withoutRows(calc.XirrV1), calc.RenderXirrResponseJSON, nil, func(t *testing.T, req calc.XirrRequestV1, resp calc.XirrResponseV1, _ []struct{}) {
+		// Independent float64 bisection of the XIRR equation.
+		first, _ := time.Parse("2006-01-02", resp.FirstDate)
+		npv := func(rate float64) float64 {
+			var sum float64
+			for _, cf := range req.CashFlows {
+				d, _ := time.Parse("2006-01-02", cf.Date)
+				years := d.Sub(first).Hours() / 24 / 365
+				sum += float64(cf.AmountCents) / math.Pow(1+rate, years)
+			}
+			return sum
+		}
+		lo, hi := -0.9999, 100.0
+		loSign := npv(lo) > 0
+		for n := 0; n < 200; n++ {
+			mid := (lo + hi) / 2
+			if (npv(mid) > 0) == loSign {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		bps := lo * 10000
+		if frac := bps - math.Floor(bps); math.Abs(frac-0.5) < 1e-6 {
+			t.Logf("float XIRR %.8f bps sits on a rounding boundary; skipping cross-check", bps)
+			return
+		}
+		if want := int64(math.Floor(bps + 0.5)); want != resp.XirrBps {
+			t.Fatalf("xirr %d bps, float cross-check %.6f bps rounds to %d", resp.XirrBps, bps, want)
+		}
+	})
+}
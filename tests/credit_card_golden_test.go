@@ -0,0 +1,93 @@
+package tests
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/nicholaskarlson/proof-first-finance-calc/internal/calc"
+)
+
+func TestCreditCardV1_Goldens(t *testing.T) {
+	runGoldens(t, "credit_card", calc.CreditCardV1, calc.RenderCreditCardResponseJSON, calc.RenderCreditCardScheduleCSV, assertCreditCardPlans)
+}
+
+// assertCreditCardPlans replays every plan: each cycle ties out, interest
+// matches a float average-daily-balance cross-check, payments follow the
+// plan's rule, and the summaries match the rows. The three-year payment
+// must be the smallest that pays off within 36 months.
+func assertCreditCardPlans(t *testing.T, req calc.CreditCardRequestV1, resp calc.CreditCardResponseV1, rows []calc.CreditCardRow) {
+	t.Helper()
+	byPlan := map[string][]calc.CreditCardRow{}
+	for _, r := range rows {
+		byPlan[r.Plan] = append(byPlan[r.Plan], r)
+	}
+	plans := []calc.CreditCardPlanV1{resp.Minimum, resp.ThreeYear}
+	if resp.Fixed != nil {
+		plans = append(plans, *resp.Fixed)
+	}
+	if len(byPlan) != len(plans) {
+		t.Fatalf("rows hold %d plans, response %d", len(byPlan), len(plans))
+	}
+
+	for _, p := range plans {
+		prs := byPlan[p.Plan]
+		bal, lastInterest := req.BalanceCents, req.StatementInterestCents
+		var interest, paid int64
+		for i, r := range prs {
+			final := i == len(prs)-1
+			if r.Cycle != i+1 {
+				t.Fatalf("%s: cycle %d at index %d", p.Plan, r.Cycle, i)
+			}
+			from, _ := time.Parse("2006-01-02", r.PaymentDate)
+			from = from.AddDate(0, 0, -resp.PaymentDueDays)
+			to, _ := time.Parse("2006-01-02", r.StatementDate)
+			days := to.Sub(from).Hours() / 24
+			after := float64(bal - r.PaymentCents)
+			if final {
+				after = 0
+			}
+			sum := float64(bal)*float64(resp.PaymentDueDays) + after*(days-float64(resp.PaymentDueDays))
+			if want := sum * float64(req.AnnualRateBps) / 10000 / 365; math.Abs(float64(r.InterestCents)-want) > 0.5+1e-6*want {
+				t.Fatalf("%s cycle %d: interest %d, want ~%.2f", p.Plan, r.Cycle, r.InterestCents, want)
+			}
+
+			var want int64
+			switch p.Plan {
+			case calc.CreditCardPlanMinimum:
+				want = max(resp.MinimumFloorCents, (bal*resp.MinimumPercentBps+5000)/10000+lastInterest)
+			case calc.CreditCardPlanFixed:
+				want = req.FixedPaymentCents
+			default:
+				want = p.FirstPaymentCents
+			}
+			if final {
+				if want < bal || r.PaymentCents != bal+r.InterestCents || r.BalanceCents != 0 {
+					t.Fatalf("%s cycle %d: final payment %d does not pay off %d", p.Plan, r.Cycle, r.PaymentCents, bal)
+				}
+			} else if r.PaymentCents != want || want >= bal || r.BalanceCents != bal-r.PaymentCents+r.InterestCents {
+				t.Fatalf("%s cycle %d does not tie out", p.Plan, r.Cycle)
+			}
+			bal, lastInterest = r.BalanceCents, r.InterestCents
+			interest += r.InterestCents
+			paid += r.PaymentCents
+		}
+		if p.MonthsToPayoff != len(prs) || p.TotalInterestCents != interest || p.TotalPaidCents != paid || paid != req.BalanceCents+interest {
+			t.Fatalf("%s: summary does not match the rows", p.Plan)
+		}
+		if p.Plan != calc.CreditCardPlanMinimum && p.InterestSavingsCents != resp.Minimum.TotalInterestCents-interest {
+			t.Fatalf("%s: interest savings %d", p.Plan, p.InterestSavingsCents)
+		}
+	}
+
+	if resp.ThreeYear.MonthsToPayoff > 36 {
+		t.Fatalf("three-year plan takes %d months", resp.ThreeYear.MonthsToPayoff)
+	}
+	if resp.ThreeYear.FirstPaymentCents > 1 && resp.ThreeYear.FirstPaymentCents < req.BalanceCents {
+		less := req
+		less.FixedPaymentCents = resp.ThreeYear.FirstPaymentCents - 1
+		if r, _, err := calc.CreditCardV1(less); err == nil && r.Fixed.MonthsToPayoff <= 36 {
+			t.Fatalf("fixed payment %d also pays off within 36 months", less.FixedPaymentCents)
+		}
+	}
+}
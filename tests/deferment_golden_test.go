@@ -0,0 +1,132 @@
+package tests
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/nicholaskarlson/proof-first-finance-calc/internal/calc"
+)
+
+func TestDefermentV1_Goldens(t *testing.T) {
+	runGoldens(t, "deferment", calc.DefermentV1, calc.RenderDefermentResponseJSON, calc.RenderDefermentScheduleCSV, assertDefermentTiesOut)
+}
+
+// assertDefermentTiesOut checks that every row ties out (principal and
+// unpaid interest carry forward through payments and capitalization), that
+// window months pay nothing, and that the totals match the rows.
+func assertDefermentTiesOut(t *testing.T, req calc.DefermentRequestV1, resp calc.DefermentResponseV1, rows []calc.DefermentRow) {
+	t.Helper()
+	bal, unpaid := req.PrincipalCents, int64(0)
+	var interest, capitalized, paid int64
+	payments, windowMonths := 0, 0
+	for _, r := range rows {
+		if r.BalanceCents != bal+r.CapitalizedCents-r.PrincipalCents {
+			t.Fatalf("row %d: balance %d does not tie out", r.Period, r.BalanceCents)
+		}
+		if r.UnpaidInterestCents != unpaid+r.InterestCents-r.InterestPaidCents-r.CapitalizedCents {
+			t.Fatalf("row %d: unpaid interest %d does not tie out", r.Period, r.UnpaidInterestCents)
+		}
+		if r.PaymentCents != r.InterestPaidCents+r.PrincipalCents {
+			t.Fatalf("row %d: payment %d != interest paid %d + principal %d", r.Period, r.PaymentCents, r.InterestPaidCents, r.PrincipalCents)
+		}
+		if r.BalanceCents < 0 || r.UnpaidInterestCents < 0 || r.PrincipalCents < 0 {
+			t.Fatalf("row %d: negative amount", r.Period)
+		}
+
+		if want := monthlyRowDate(t, req.StartDate, r.Period-1); r.Date != want {
+			t.Fatalf("row %d: date %s, want %s", r.Period, r.Date, want)
+		}
+
+		w := defermentWindow(req, r.Date)
+		switch {
+		case w < 0 && r.Status != "repayment":
+			t.Fatalf("row %d: status %s outside any window", r.Period, r.Status)
+		case w >= 0 && (r.Status != req.Windows[w].Kind || r.PaymentCents != 0):
+			t.Fatalf("row %d: status %s payment %d inside windows[%d]", r.Period, r.Status, r.PaymentCents, w)
+		}
+		if w >= 0 {
+			windowMonths++
+		} else {
+			payments++
+		}
+
+		// Interest is a month of 30/360 interest on principal only.
+		want := float64(bal) * float64(req.AnnualRateBps) / 10000 / 12
+		if w >= 0 && req.Windows[w].Subsidized {
+			want = 0
+		}
+		if math.Abs(want-float64(r.InterestCents)) > 0.5+1e-9 {
+			t.Fatalf("row %d: interest %d, float cross-check %.4f", r.Period, r.InterestCents, want)
+		}
+
+		bal, unpaid = r.BalanceCents, r.UnpaidInterestCents
+		interest += r.InterestCents
+		capitalized += r.CapitalizedCents
+		paid += r.PaymentCents
+	}
+
+	if bal != 0 || unpaid != 0 {
+		t.Fatalf("schedule ends owing %d principal and %d interest", bal, unpaid)
+	}
+	if payments > req.TermMonths || resp.NumMonths != len(rows) || resp.NonPaymentMonths != windowMonths {
+		t.Fatalf("%d payments over %d months (%d in windows) for term %d", payments, len(rows), windowMonths, req.TermMonths)
+	}
+	if resp.TotalInterestCents != interest || resp.TotalCapitalizedCents != capitalized || resp.TotalPaidCents != paid {
+		t.Fatalf("totals do not match the rows")
+	}
+	if paid != req.PrincipalCents+interest {
+		t.Fatalf("paid %d != principal %d + interest %d", paid, req.PrincipalCents, interest)
+	}
+
+	// Without windows the amounts are exactly Amortize v1's, and so are the
+	// dates unless start_date is past the 28th (Amortize v1 overflows those).
+	if len(req.Windows) == 0 && len(req.CapitalizationDates) == 0 {
+		_, amort, err := calc.AmortizeV1(calc.AmortizeRequestV1{
+			PrincipalCents: req.PrincipalCents,
+			AnnualRateBps:  req.AnnualRateBps,
+			TermMonths:     req.TermMonths,
+			StartDate:      req.StartDate,
+		})
+		if err != nil {
+			t.Fatalf("AmortizeV1: %v", err)
+		}
+		start, err := time.Parse("2006-01-02", req.StartDate)
+		if err != nil {
+			t.Fatalf("start_date: %v", err)
+		}
+		if len(amort) != len(rows) {
+			t.Fatalf("rows %d, AmortizeV1 rows %d", len(rows), len(amort))
+		}
+		for i, a := range amort {
+			r := rows[i]
+			if (r.Date != a.Date && start.Day() <= 28) || r.PaymentCents != a.PaymentCents || r.PrincipalCents != a.PrincipalCents || r.InterestCents != a.InterestCents || r.BalanceCents != a.BalanceCents {
+				t.Fatalf("row %d differs from AmortizeV1", r.Period)
+			}
+		}
+	}
+}
+
+// monthlyRowDate returns start plus months calendar months, clamped to the
+// last day of a shorter month.
+func monthlyRowDate(t *testing.T, start string, months int) string {
+	t.Helper()
+	d, err := time.Parse("2006-01-02", start)
+	if err != nil {
+		t.Fatalf("start_date: %v", err)
+	}
+	first := time.Date(d.Year(), d.Month()+time.Month(months), 1, 0, 0, 0, 0, time.UTC)
+	last := first.AddDate(0, 1, -1).Day()
+	return time.Date(first.Year(), first.Month(), min(d.Day(), last), 0, 0, 0, 0, time.UTC).Format("2006-01-02")
+}
+
+// defermentWindow returns the index of the request window containing date,
+// or -1. Dates are ISO strings, so they compare as strings.
+func defermentWindow(req calc.DefermentRequestV1, date string) int {
+	for k, w := range req.Windows {
+		if w.StartDate <= date && date <= w.EndDate {
+			return k
+		}
+	}
+	return -1
+}
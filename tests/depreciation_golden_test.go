@@ -0,0 +1,116 @@
+package tests
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/nicholaskarlson/proof-first-finance-calc/internal/calc"
+)
+
+func TestDepreciationV1_Goldens(t *testing.T) {
+	runGoldens(t, "depreciation", calc.DepreciationV1, calc.RenderDepreciationResponseJSON, calc.RenderDepreciationScheduleCSV, assertDepreciationInvariants)
+}
+
+// TestDepreciationV1_MACRSTablesMatchIRSMethod recomputes every GDS table
+// from the method it tabulates (200% or 150% declining balance switching to
+// straight-line, with the half-year or mid-quarter first-year fraction) and
+// requires each published percentage within its rounding.
+func TestDepreciationV1_MACRSTablesMatchIRSMethod(t *testing.T) {
+	const cost = 100_000_000 // cents; 1 cent = 0.000001%
+	quarters := []struct {
+		convention string
+		month      time.Month
+		firstYear  float64
+	}{
+		{calc.MACRSConventionHalfYear, time.June, 0.5},
+		{calc.MACRSConventionMidQuarter, time.February, 10.5 / 12},
+		{calc.MACRSConventionMidQuarter, time.May, 7.5 / 12},
+		{calc.MACRSConventionMidQuarter, time.August, 4.5 / 12},
+		{calc.MACRSConventionMidQuarter, time.November, 1.5 / 12},
+	}
+	for _, q := range quarters {
+		for _, life := range []int{3, 5, 7, 10, 15, 20} {
+			req := calc.DepreciationRequestV1{
+				Method:              calc.DepreciationMACRS,
+				CostCents:           cost,
+				LifeYears:           life,
+				PlacedInServiceDate: time.Date(2026, q.month, 1, 0, 0, 0, 0, time.UTC).Format("2006-01-02"),
+				Convention:          q.convention,
+			}
+			resp, rows, err := calc.DepreciationV1(req)
+			if err != nil {
+				t.Fatalf("DepreciationV1: %v", err)
+			}
+			assertDepreciationInvariants(t, req, resp, rows)
+			if len(rows) != life+1 {
+				t.Fatalf("%s %d-year: %d rows, want %d", resp.MACRSTable, life, len(rows), life+1)
+			}
+
+			factor := 2.0
+			if life >= 15 {
+				factor = 1.5
+			}
+			rate := factor / float64(life)
+			remaining := float64(cost)
+			for i, r := range rows[:life] {
+				want := remaining * rate * q.firstYear
+				if i > 0 {
+					want = math.Max(remaining*rate, remaining/(float64(life)-q.firstYear-float64(i-1)))
+				}
+				remaining -= want
+				// Tables are published to 0.01% (0.001% for 20-year).
+				if math.Abs(float64(r.DepreciationCents)-want) > 0.015*cost/100 {
+					t.Fatalf("%s %d-year year %d: %d, IRS method %.0f", resp.MACRSTable, life, r.Period, r.DepreciationCents, want)
+				}
+			}
+		}
+	}
+}
+
+func assertDepreciationInvariants(t *testing.T, req calc.DepreciationRequestV1, resp calc.DepreciationResponseV1, rows []calc.DepreciationRow) {
+	t.Helper()
+	if resp.NumPeriods != len(rows) {
+		t.Fatalf("num_periods %d, %d rows", resp.NumPeriods, len(rows))
+	}
+	if resp.FinalBookValueCents != req.SalvageValueCents || rows[len(rows)-1].BookValueCents != req.SalvageValueCents {
+		t.Fatalf("final book value %d must equal salvage %d", resp.FinalBookValueCents, req.SalvageValueCents)
+	}
+	if resp.TotalDepreciationCents != req.CostCents-req.SalvageValueCents {
+		t.Fatalf("total depreciation %d != cost %d - salvage %d", resp.TotalDepreciationCents, req.CostCents, req.SalvageValueCents)
+	}
+
+	start, _ := time.Parse("2006-01-02", req.PlacedInServiceDate)
+	var sum int64
+	for i, r := range rows {
+		sum += r.DepreciationCents
+		if r.Period != i+1 || r.Year != start.Year()+i {
+			t.Fatalf("row %d: period %d year %d", i, r.Period, r.Year)
+		}
+		if r.DepreciationCents < 0 {
+			t.Fatalf("row %d: negative depreciation %d", r.Period, r.DepreciationCents)
+		}
+		if r.AccumulatedDepreciationCents != sum || r.BookValueCents != req.CostCents-sum {
+			t.Fatalf("row %d: accumulated %d / book %d do not tie to %d", r.Period, r.AccumulatedDepreciationCents, r.BookValueCents, sum)
+		}
+	}
+
+	// Straight-line and SYD keep every year within a cent of the exact
+	// fraction of the depreciable amount.
+	depreciable := float64(req.CostCents - req.SalvageValueCents)
+	n := float64(req.LifeYears)
+	for i, r := range rows {
+		var exact float64
+		switch req.Method {
+		case calc.DepreciationStraightLine:
+			exact = depreciable / n
+		case calc.DepreciationSumOfYearsDigits:
+			exact = depreciable * (n - float64(i)) / (n * (n + 1) / 2)
+		default:
+			return
+		}
+		if math.Abs(float64(r.DepreciationCents)-exact) > 1 {
+			t.Fatalf("row %d: %d cents, exact %.4f", r.Period, r.DepreciationCents, exact)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/nicholaskarlson/proof-first-finance-calc/internal/calc"
+)
+
+// runGoldens checks a calculator's fixtures under fixtures/<dir> (the
+// amortize fixtures sit directly under fixtures/, dir ""). A case with an
+// error.txt must fail with that error; any other must pass invariants and
+// render to its response.json and, unless renderCSV is nil, schedule.csv.
+func runGoldens[Req, Resp, Row any](t *testing.T, dir string, compute func(Req) (Resp, []Row, error), renderJSON func(Resp) ([]byte, error), renderCSV func([]Row) ([]byte, error), invariants func(*testing.T, Req, Resp, []Row)) {
+	withCal := func(req Req, _ *calc.HolidayCalendar) (Resp, []Row, error) { return compute(req) }
+	check := func(t *testing.T, req Req, _ *calc.HolidayCalendar, resp Resp, rows []Row) {
+		invariants(t, req, resp, rows)
+	}
+	runCalendarGoldens(t, dir, withCal, renderJSON, renderCSV, check)
+}
+
+// runCalendarGoldens is runGoldens for a calculator that takes the case's
+// holidays.txt calendar (nil without one).
+func runCalendarGoldens[Req, Resp, Row any](t *testing.T, dir string, compute func(Req, *calc.HolidayCalendar) (Resp, []Row, error), renderJSON func(Resp) ([]byte, error), renderCSV func([]Row) ([]byte, error), invariants func(*testing.T, Req, *calc.HolidayCalendar, Resp, []Row)) {
+	root := filepath.Join("..", "fixtures", dir)
+	inRoot := filepath.Join(root, "input")
+
+	for _, c := range fixtureCases(t, inRoot) {
+		c := c
+		t.Run(c, func(t *testing.T) {
+			inB, err := os.ReadFile(filepath.Join(inRoot, c, "request.json"))
+			if err != nil {
+				t.Fatalf("read input request: %v", err)
+			}
+			var req Req
+			if err := json.Unmarshal(inB, &req); err != nil {
+				t.Fatalf("unmarshal request: %v", err)
+			}
+			cal := loadCaseCalendar(t, filepath.Join(inRoot, c))
+
+			expDir := filepath.Join(root, "expected", c)
+			errPath := filepath.Join(expDir, "error.txt")
+			if _, statErr := os.Stat(errPath); statErr == nil {
+				// Expected-fail case: compare stable one-line error body.
+				_, _, err := compute(req, cal)
+				if err == nil {
+					t.Fatalf("expected error, got nil")
+				}
+				assertGolden(t, errPath, []byte("error: "+err.Error()+"\n"))
+				return
+			}
+
+			resp, rows, err := compute(req, cal)
+			if err != nil {
+				t.Fatalf("compute: %v", err)
+			}
+			invariants(t, req, cal, resp, rows)
+
+			gotResp, err := renderJSON(resp)
+			if err != nil {
+				t.Fatalf("render response json: %v", err)
+			}
+			assertGolden(t, filepath.Join(expDir, "response.json"), gotResp)
+
+			if renderCSV == nil {
+				return
+			}
+			gotCSV, err := renderCSV(rows)
+			if err != nil {
+				t.Fatalf("render schedule csv: %v", err)
+			}
+			assertGolden(t, filepath.Join(expDir, "schedule.csv"), gotCSV)
+		})
+	}
+}
+
+// withoutRows adapts a calculator without a schedule to runGoldens.
+func withoutRows[Req, Resp any](compute func(Req) (Resp, error)) func(Req) (Resp, []struct{}, error) {
+	return func(req Req) (Resp, []struct{}, error) {
+		resp, err := compute(req)
+		return resp, nil, err
+	}
+}
+
+// assertGolden compares got to the golden file at path.
+func assertGolden(t *testing.T, path string, got []byte) {
+	t.Helper()
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read expected %s: %v", filepath.Base(path), err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("%s mismatch\n--- got ---\n%s\n--- want ---\n%s", filepath.Base(path), string(got), string(want))
+	}
+}
+
+// fixtureCases returns the sorted case directory names under inRoot.
+func fixtureCases(t *testing.T, inRoot string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(inRoot)
+	if err != nil {
+		t.Fatalf("read fixtures input: %v", err)
+	}
+
+	caseNames := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if e.IsDir() {
+			caseNames = append(caseNames, e.Name())
+		}
+	}
+	sort.Strings(caseNames)
+	return caseNames
+}
+
+// loadCaseCalendar returns the case's holidays.txt calendar, or nil if the
+// case has none.
+func loadCaseCalendar(t *testing.T, caseDir string) *calc.HolidayCalendar {
+	t.Helper()
+	f, err := os.Open(filepath.Join(caseDir, "holidays.txt"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		t.Fatalf("open holidays: %v", err)
+	}
+	defer f.Close()
+	cal, err := calc.ParseHolidayCalendar(f)
+	if err != nil {
+		t.Fatalf("parse holidays: %v", err)
+	}
+	return cal
+}
@@ -0,0 +1,117 @@
+package tests
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/nicholaskarlson/proof-first-finance-calc/internal/calc"
+)
+
+func TestGraduatedV1_Goldens(t *testing.T) {
+	runGoldens(t, "graduated", calc.GraduatedV1, calc.RenderGraduatedResponseJSON, calc.RenderGraduatedScheduleCSV, assertGraduatedInvariants)
+}
+
+func assertGraduatedInvariants(t *testing.T, req calc.GraduatedRequestV1, resp calc.GraduatedResponseV1, rows []calc.GraduatedRow) {
+	t.Helper()
+	// Minimal: the solved initial payment pays off in the term, one cent
+	// less does not.
+	if !graduatedPaysOff(req, resp.Steps, resp.InitialPaymentCents) {
+		t.Fatalf("initial payment %d does not pay off the loan", resp.InitialPaymentCents)
+	}
+	if resp.InitialPaymentCents > 0 && graduatedPaysOff(req, resp.Steps, resp.InitialPaymentCents-1) {
+		t.Fatalf("initial payment %d is not minimal", resp.InitialPaymentCents)
+	}
+
+	// Step payments are the initial payment times the compounded increases.
+	m := big.NewRat(1, 1)
+	for k, s := range resp.Steps {
+		if k > 0 {
+			m.Mul(m, big.NewRat(10000+graduatedIncrease(req, k), 10000))
+		}
+		if want := monthlyRowDate(t, req.StartDate, s.Period-1); s.Date != want {
+			t.Fatalf("step %d: date %s, want %s", k, s.Date, want)
+		}
+		want := new(big.Rat).Mul(m, new(big.Rat).SetInt64(resp.InitialPaymentCents))
+		got := new(big.Rat).SetInt64(s.PaymentCents)
+		if diff := new(big.Rat).Sub(got, want); diff.Cmp(big.NewRat(1, 2)) > 0 || diff.Cmp(big.NewRat(-1, 2)) <= 0 {
+			t.Fatalf("step %d payment %d, want %s", k, s.PaymentCents, want.FloatString(4))
+		}
+	}
+
+	bal := req.PrincipalCents
+	var interest, paid, deferred int64
+	negPeriods := 0
+	for i, r := range rows {
+		if r.Period != i+1 || r.BalanceCents != bal-r.PrincipalCents || r.PaymentCents != r.PrincipalCents+r.InterestCents {
+			t.Fatalf("row %d does not tie out", r.Period)
+		}
+		if want := monthlyRowDate(t, req.StartDate, i); r.Date != want {
+			t.Fatalf("row %d: date %s, want %s", r.Period, r.Date, want)
+		}
+		if r.NegativeAmortization != (r.PrincipalCents < 0) {
+			t.Fatalf("row %d: negative amortization flag %v with principal %d", r.Period, r.NegativeAmortization, r.PrincipalCents)
+		}
+		step := resp.Steps[r.Step]
+		if r.Period < step.Period || (r.Step+1 < len(resp.Steps) && r.Period >= resp.Steps[r.Step+1].Period) {
+			t.Fatalf("row %d is not in step %d", r.Period, r.Step)
+		}
+		if i < len(rows)-1 && r.PaymentCents != step.PaymentCents {
+			t.Fatalf("row %d: payment %d, step payment %d", r.Period, r.PaymentCents, step.PaymentCents)
+		}
+		if i == len(rows)-1 && r.PaymentCents > step.PaymentCents {
+			t.Fatalf("last payment %d exceeds step payment %d", r.PaymentCents, step.PaymentCents)
+		}
+		if r.NegativeAmortization {
+			negPeriods++
+			deferred -= r.PrincipalCents
+		}
+		bal = r.BalanceCents
+		interest += r.InterestCents
+		paid += r.PaymentCents
+	}
+	if bal != 0 || len(rows) != req.TermMonths {
+		t.Fatalf("schedule ends at balance %d after %d rows, want 0 after %d", bal, len(rows), req.TermMonths)
+	}
+	if resp.TotalInterestCents != interest || resp.TotalPaidCents != paid || paid != req.PrincipalCents+interest {
+		t.Fatalf("totals do not match the rows")
+	}
+	if resp.NegativeAmortizationPeriods != negPeriods || resp.DeferredInterestCents != deferred || resp.NegativeAmortization != (negPeriods > 0) {
+		t.Fatalf("negative amortization summary does not match the rows")
+	}
+}
+
+// graduatedIncrease returns step k's increase in bps from the request.
+func graduatedIncrease(req calc.GraduatedRequestV1, k int) int64 {
+	if len(req.Steps) > 0 {
+		return req.Steps[k-1].IncreaseBps
+	}
+	return req.StepIncreaseBps
+}
+
+// graduatedPaysOff replays the schedule for an initial payment with plain
+// integer arithmetic and reports whether some month's balance plus
+// interest fits in its step payment.
+func graduatedPaysOff(req calc.GraduatedRequestV1, steps []calc.GraduatedStepV1, initial int64) bool {
+	pay := make([]int64, len(steps))
+	m := big.NewRat(1, 1)
+	for k := range steps {
+		if k > 0 {
+			m.Mul(m, big.NewRat(10000+graduatedIncrease(req, k), 10000))
+		}
+		v := new(big.Rat).Mul(m, new(big.Rat).SetInt64(initial))
+		v.Add(v, big.NewRat(1, 2))
+		pay[k] = new(big.Int).Quo(v.Num(), v.Denom()).Int64()
+	}
+	bal, k := req.PrincipalCents, 0
+	for i := 1; i <= req.TermMonths; i++ {
+		for k+1 < len(steps) && steps[k+1].Period <= i {
+			k++
+		}
+		interest := (2*bal*req.AnnualRateBps + 120000) / 240000
+		if bal+interest <= pay[k] {
+			return true
+		}
+		bal += interest - pay[k]
+	}
+	return false
+}
@@ -7,79 +7,299 @@ import (
 	"net/http/httptest"
 	"os"
 	"path/filepath"
-	"sort"
 	"testing"
 
 	"github.com/nicholaskarlson/proof-first-finance-calc/internal/api"
 )
 
 func TestHTTPAPI_V1_Amortize_Fixtures(t *testing.T) {
-	root := filepath.Join("..", "fixtures")
-	inRoot := filepath.Join(root, "input")
-	entries, err := os.ReadDir(inRoot)
+	defaultSrv := httptest.NewServer(api.Handler())
+	defer defaultSrv.Close()
+
+	inRoot := filepath.Join("..", "fixtures", "input")
+	for _, c := range fixtureCases(t, inRoot) {
+		c := c
+		t.Run(c, func(t *testing.T) {
+			// Cases with a holiday calendar get their own server.
+			srv := defaultSrv
+			if cal := loadCaseCalendar(t, filepath.Join(inRoot, c)); cal != nil {
+				srv = httptest.NewServer(api.HandlerWithOptions(api.Options{Holidays: cal}))
+				defer srv.Close()
+			}
+			checkHTTPCase(t, srv, "", c, "/v1/amortize")
+		})
+	}
+}
+
+func TestHTTPAPI_V1_Arm_Fixtures(t *testing.T) {
+	defaultSrv := httptest.NewServer(api.Handler())
+	defer defaultSrv.Close()
+
+	inRoot := filepath.Join("..", "fixtures", "arm", "input")
+	for _, c := range fixtureCases(t, inRoot) {
+		c := c
+		t.Run(c, func(t *testing.T) {
+			srv := defaultSrv
+			if cal := loadCaseCalendar(t, filepath.Join(inRoot, c)); cal != nil {
+				srv = httptest.NewServer(api.HandlerWithOptions(api.Options{Holidays: cal}))
+				defer srv.Close()
+			}
+			checkHTTPCase(t, srv, "arm", c, "/v1/arm")
+		})
+	}
+}
+
+// checkHTTPCase posts fixture case c of the suite under fixtures/<dir> to
+// route and route/schedule.csv and compares the bodies to the goldens.
+func checkHTTPCase(t *testing.T, srv *httptest.Server, dir, c, route string) {
+	t.Helper()
+	checkHTTPCaseRoutes(t, srv, dir, c, route, true)
+}
+
+// checkHTTPCaseRoutes is checkHTTPCase for calculators with or without a
+// schedule.csv route.
+func checkHTTPCaseRoutes(t *testing.T, srv *httptest.Server, dir, c, route string, withCSV bool) {
+	t.Helper()
+	root := filepath.Join("..", "fixtures", dir)
+	body, err := os.ReadFile(filepath.Join(root, "input", c, "request.json"))
 	if err != nil {
-		t.Fatalf("read fixtures input: %v", err)
+		t.Fatalf("read request: %v", err)
+	}
+
+	expDir := filepath.Join(root, "expected", c)
+	_, errStat := os.Stat(filepath.Join(expDir, "error.txt"))
+	hasErr := errStat == nil
+
+	check := func(path string, wantFile string, wantStatus int) {
+		r, err := http.Post(srv.URL+path, "application/json", bytes.NewReader(body))
+		if err != nil {
+			t.Fatalf("POST %s: %v", path, err)
+		}
+		defer r.Body.Close()
+
+		got, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatalf("read response: %v", err)
+		}
+
+		if r.StatusCode != wantStatus {
+			t.Fatalf("status mismatch: got %d want %d\nbody:\n%s", r.StatusCode, wantStatus, string(got))
+		}
+
+		want, err := os.ReadFile(filepath.Join(expDir, wantFile))
+		if err != nil {
+			t.Fatalf("read expected %s: %v", wantFile, err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Fatalf("%s mismatch\n--- got ---\n%s\n--- want ---\n%s", wantFile, string(got), string(want))
+		}
 	}
 
-	caseNames := make([]string, 0, len(entries))
-	for _, e := range entries {
-		if e.IsDir() {
-			caseNames = append(caseNames, e.Name())
+	if hasErr {
+		check(route, "error.txt", http.StatusBadRequest)
+		if withCSV {
+			check(route+"/schedule.csv", "error.txt", http.StatusBadRequest)
 		}
+		return
 	}
-	sort.Strings(caseNames)
 
+	check(route, "response.json", http.StatusOK)
+	if withCSV {
+		check(route+"/schedule.csv", "schedule.csv", http.StatusOK)
+	}
+}
+
+func TestHTTPAPI_V1_Solve_Fixtures(t *testing.T) {
 	srv := httptest.NewServer(api.Handler())
 	defer srv.Close()
 
-	for _, c := range caseNames {
+	for _, s := range []struct{ dir, route string }{
+		{"solve_term", "/v1/solve/term"},
+		{"solve_rate", "/v1/solve/rate"},
+		{"solve_principal", "/v1/solve/principal"},
+	} {
+		for _, c := range fixtureCases(t, filepath.Join("..", "fixtures", s.dir, "input")) {
+			s, c := s, c
+			t.Run(s.dir+"/"+c, func(t *testing.T) {
+				checkHTTPCase(t, srv, s.dir, c, s.route)
+			})
+		}
+	}
+}
+
+func TestHTTPAPI_V1_Apr_Fixtures(t *testing.T) {
+	srv := httptest.NewServer(api.Handler())
+	defer srv.Close()
+
+	for _, c := range fixtureCases(t, filepath.Join("..", "fixtures", "apr", "input")) {
 		c := c
 		t.Run(c, func(t *testing.T) {
-			reqPath := filepath.Join(inRoot, c, "request.json")
-			body, err := os.ReadFile(reqPath)
-			if err != nil {
-				t.Fatalf("read request: %v", err)
-			}
+			checkHTTPCase(t, srv, "apr", c, "/v1/apr")
+		})
+	}
+}
 
-			expDir := filepath.Join(root, "expected", c)
-			errPath := filepath.Join(expDir, "error.txt")
-			_, errStat := os.Stat(errPath)
-			hasErr := errStat == nil
-
-			// JSON endpoint
-			check := func(path string, wantFile string, wantStatus int) {
-				r, err := http.Post(srv.URL+path, "application/json", bytes.NewReader(body))
-				if err != nil {
-					t.Fatalf("POST %s: %v", path, err)
-				}
-				defer r.Body.Close()
-
-				got, err := io.ReadAll(r.Body)
-				if err != nil {
-					t.Fatalf("read response: %v", err)
-				}
-
-				if r.StatusCode != wantStatus {
-					t.Fatalf("status mismatch: got %d want %d\nbody:\n%s", r.StatusCode, wantStatus, string(got))
-				}
-
-				want, err := os.ReadFile(filepath.Join(expDir, wantFile))
-				if err != nil {
-					t.Fatalf("read expected %s: %v", wantFile, err)
-				}
-				if !bytes.Equal(got, want) {
-					t.Fatalf("%s mismatch\n--- got ---\n%s\n--- want ---\n%s", wantFile, string(got), string(want))
-				}
-			}
+func TestHTTPAPI_V1_CashFlow_Fixtures(t *testing.T) {
+	srv := httptest.NewServer(api.Handler())
+	defer srv.Close()
 
-			if hasErr {
-				check("/v1/amortize", "error.txt", http.StatusBadRequest)
-				check("/v1/amortize/schedule.csv", "error.txt", http.StatusBadRequest)
-				return
-			}
+	for _, dir := range []string{"npv", "irr", "xirr"} {
+		for _, c := range fixtureCases(t, filepath.Join("..", "fixtures", dir, "input")) {
+			dir, c := dir, c
+			t.Run(dir+"/"+c, func(t *testing.T) {
+				checkHTTPCaseRoutes(t, srv, dir, c, "/v1/"+dir, false)
+			})
+		}
+	}
+}
+
+func TestHTTPAPI_V1_Bond_Fixtures(t *testing.T) {
+	srv := httptest.NewServer(api.Handler())
+	defer srv.Close()
+
+	for _, b := range []struct{ dir, route string }{
+		{"bond_price", "/v1/bond/price"},
+		{"bond_yield", "/v1/bond/yield"},
+	} {
+		for _, c := range fixtureCases(t, filepath.Join("..", "fixtures", b.dir, "input")) {
+			b, c := b, c
+			t.Run(b.dir+"/"+c, func(t *testing.T) {
+				checkHTTPCase(t, srv, b.dir, c, b.route)
+			})
+		}
+	}
+}
+
+func TestHTTPAPI_V1_Depreciation_Fixtures(t *testing.T) {
+	srv := httptest.NewServer(api.Handler())
+	defer srv.Close()
+
+	for _, c := range fixtureCases(t, filepath.Join("..", "fixtures", "depreciation", "input")) {
+		c := c
+		t.Run(c, func(t *testing.T) {
+			checkHTTPCase(t, srv, "depreciation", c, "/v1/depreciation")
+		})
+	}
+}
+
+func TestHTTPAPI_V1_Savings_Fixtures(t *testing.T) {
+	srv := httptest.NewServer(api.Handler())
+	defer srv.Close()
+
+	for _, dir := range []string{"future_value", "annuity", "sinking_fund"} {
+		for _, c := range fixtureCases(t, filepath.Join("..", "fixtures", dir, "input")) {
+			dir, c := dir, c
+			t.Run(dir+"/"+c, func(t *testing.T) {
+				checkHTTPCase(t, srv, dir, c, "/v1/savings/"+dir)
+			})
+		}
+	}
+}
+
+func TestHTTPAPI_V1_Graduated_Fixtures(t *testing.T) {
+	srv := httptest.NewServer(api.Handler())
+	defer srv.Close()
+
+	for _, c := range fixtureCases(t, filepath.Join("..", "fixtures", "graduated", "input")) {
+		c := c
+		t.Run(c, func(t *testing.T) {
+			checkHTTPCase(t, srv, "graduated", c, "/v1/graduated")
+		})
+	}
+}
+
+func TestHTTPAPI_V1_NegAm_Fixtures(t *testing.T) {
+	srv := httptest.NewServer(api.Handler())
+	defer srv.Close()
+
+	for _, c := range fixtureCases(t, filepath.Join("..", "fixtures", "negam", "input")) {
+		c := c
+		t.Run(c, func(t *testing.T) {
+			checkHTTPCase(t, srv, "negam", c, "/v1/negam")
+		})
+	}
+}
+
+func TestHTTPAPI_V1_Payoff_Fixtures(t *testing.T) {
+	srv := httptest.NewServer(api.Handler())
+	defer srv.Close()
 
-			check("/v1/amortize", "response.json", http.StatusOK)
-			check("/v1/amortize/schedule.csv", "schedule.csv", http.StatusOK)
+	for _, c := range fixtureCases(t, filepath.Join("..", "fixtures", "payoff", "input")) {
+		c := c
+		t.Run(c, func(t *testing.T) {
+			checkHTTPCase(t, srv, "payoff", c, "/v1/payoff")
+		})
+	}
+}
+
+func TestHTTPAPI_V1_CreditCard_Fixtures(t *testing.T) {
+	srv := httptest.NewServer(api.Handler())
+	defer srv.Close()
+
+	for _, c := range fixtureCases(t, filepath.Join("..", "fixtures", "credit_card", "input")) {
+		c := c
+		t.Run(c, func(t *testing.T) {
+			checkHTTPCase(t, srv, "credit_card", c, "/v1/credit_card")
+		})
+	}
+}
+
+func TestHTTPAPI_V1_Deferment_Fixtures(t *testing.T) {
+	srv := httptest.NewServer(api.Handler())
+	defer srv.Close()
+
+	for _, c := range fixtureCases(t, filepath.Join("..", "fixtures", "deferment", "input")) {
+		c := c
+		t.Run(c, func(t *testing.T) {
+			checkHTTPCase(t, srv, "deferment", c, "/v1/deferment")
+		})
+	}
+}
+
+func TestHTTPAPI_V1_Piti_Fixtures(t *testing.T) {
+	srv := httptest.NewServer(api.Handler())
+	defer srv.Close()
+
+	for _, c := range fixtureCases(t, filepath.Join("..", "fixtures", "piti", "input")) {
+		c := c
+		t.Run(c, func(t *testing.T) {
+			checkHTTPCase(t, srv, "piti", c, "/v1/piti")
+		})
+	}
+}
+
+func TestHTTPAPI_V1_Precomputed_Fixtures(t *testing.T) {
+	srv := httptest.NewServer(api.Handler())
+	defer srv.Close()
+
+	for _, c := range fixtureCases(t, filepath.Join("..", "fixtures", "precomputed", "input")) {
+		c := c
+		t.Run(c, func(t *testing.T) {
+			checkHTTPCase(t, srv, "precomputed", c, "/v1/precomputed")
+		})
+	}
+}
+
+func TestHTTPAPI_V1_Refinance_Fixtures(t *testing.T) {
+	srv := httptest.NewServer(api.Handler())
+	defer srv.Close()
+
+	for _, c := range fixtureCases(t, filepath.Join("..", "fixtures", "refinance", "input")) {
+		c := c
+		t.Run(c, func(t *testing.T) {
+			checkHTTPCase(t, srv, "refinance", c, "/v1/refinance")
+		})
+	}
+}
+
+func TestHTTPAPI_V1_SimpleInterest_Fixtures(t *testing.T) {
+	srv := httptest.NewServer(api.Handler())
+	defer srv.Close()
+
+	for _, c := range fixtureCases(t, filepath.Join("..", "fixtures", "simple_interest", "input")) {
+		c := c
+		t.Run(c, func(t *testing.T) {
+			checkHTTPCase(t, srv, "simple_interest", c, "/v1/simple_interest")
 		})
 	}
 }
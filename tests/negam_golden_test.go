@@ -0,0 +1,78 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/nicholaskarlson/proof-first-finance-calc/internal/calc"
+)
+
+func TestNegAmV1_Goldens(t *testing.T) {
+	runGoldens(t, "negam", calc.NegAmV1, calc.RenderNegAmResponseJSON, calc.RenderNegAmScheduleCSV, assertNegAmLedger)
+}
+
+// assertNegAmLedger proves the signed ledger balances: every row ties out,
+// deferred interest is exactly the negative principal, the balance never
+// passes the recast limit, and the loan ends at zero.
+func assertNegAmLedger(t *testing.T, req calc.NegAmRequestV1, resp calc.NegAmResponseV1, rows []calc.NegAmRow) {
+	t.Helper()
+	if resp.MaxBalanceCents*10000 < req.PrincipalCents*resp.MaxBalanceBps-5000 || resp.MaxBalanceCents*10000 > req.PrincipalCents*resp.MaxBalanceBps+5000 {
+		t.Fatalf("max balance %d is not %d bps of %d", resp.MaxBalanceCents, resp.MaxBalanceBps, req.PrincipalCents)
+	}
+
+	bal, pmt := req.PrincipalCents, req.MinimumPaymentCents
+	var interest, paid, deferred int64
+	negPeriods, events, recast := 0, 0, false
+	for i, r := range rows {
+		if r.Period != i+1 || r.PaymentCents != r.PrincipalCents+r.InterestCents || r.BalanceCents != bal-r.PrincipalCents {
+			t.Fatalf("row %d does not tie out", r.Period)
+		}
+		if want := monthlyRowDate(t, req.StartDate, i); r.Date != want {
+			t.Fatalf("row %d: date %s, want %s", r.Period, r.Date, want)
+		}
+		if want := max(0, -r.PrincipalCents); r.DeferredInterestCents != want {
+			t.Fatalf("row %d: deferred interest %d, want %d", r.Period, r.DeferredInterestCents, want)
+		}
+		if r.BalanceCents*10000 > req.PrincipalCents*resp.MaxBalanceBps {
+			t.Fatalf("row %d: balance %d above the recast limit", r.Period, r.BalanceCents)
+		}
+		// A row's events are listed in order; the row shows the last.
+		last := ""
+		for ; events < len(resp.Events) && resp.Events[events].Period == r.Period; events++ {
+			e := resp.Events[events]
+			if e.BalanceCents != bal {
+				t.Fatalf("row %d: events[%d] balance %d, want %d", r.Period, events, e.BalanceCents, bal)
+			}
+			last, pmt = e.Event, e.PaymentCents
+			recast = recast || e.Event != calc.NegAmEventPaymentChange
+		}
+		if r.Event != last {
+			t.Fatalf("row %d: event %q, last listed event %q", r.Period, r.Event, last)
+		}
+		if recast && r.PrincipalCents < 0 {
+			t.Fatalf("row %d: negative amortization after a recast", r.Period)
+		}
+		if i < len(rows)-1 && r.PaymentCents != pmt {
+			t.Fatalf("row %d: payment %d, want %d", r.Period, r.PaymentCents, pmt)
+		}
+		if r.PrincipalCents < 0 {
+			negPeriods++
+		}
+		bal = r.BalanceCents
+		interest += r.InterestCents
+		paid += r.PaymentCents
+		deferred += r.DeferredInterestCents
+	}
+
+	if bal != 0 {
+		t.Fatalf("schedule ends at balance %d", bal)
+	}
+	if events != len(resp.Events) {
+		t.Fatalf("%d row events, %d response events", events, len(resp.Events))
+	}
+	if resp.TotalInterestCents != interest || resp.TotalPaidCents != paid || paid != req.PrincipalCents+interest {
+		t.Fatalf("totals do not match the rows")
+	}
+	if resp.NegativeAmortizationPeriods != negPeriods || resp.TotalDeferredInterestCents != deferred {
+		t.Fatalf("negative amortization summary does not match the rows")
+	}
+}
@@ -0,0 +1,68 @@
+package tests
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/nicholaskarlson/proof-first-finance-calc/internal/calc"
+)
+
+func TestPayoffV1_Goldens(t *testing.T) {
+	runCalendarGoldens(t, "payoff", calc.PayoffV1WithCalendar, calc.RenderPayoffResponseJSON, calc.RenderScheduleCSV, assertPayoffInvariants)
+}
+
+func assertPayoffInvariants(t *testing.T, req calc.PayoffRequestV1, cal *calc.HolidayCalendar, resp calc.PayoffResponseV1, rows []calc.ScheduleRow) {
+	t.Helper()
+	// The paid rows are exactly the head of the Amortize v1 schedule.
+	_, schedule, err := calc.AmortizeV1WithCalendar(req.AmortizeRequestV1, cal)
+	if err != nil {
+		t.Fatalf("AmortizeV1: %v", err)
+	}
+	if len(rows) != req.PaymentsMade || !reflect.DeepEqual(rows, schedule[:len(rows)]) {
+		t.Fatalf("paid rows are not the first %d schedule rows", req.PaymentsMade)
+	}
+	if len(rows) > 0 && resp.PrincipalBalanceCents != rows[len(rows)-1].BalanceCents {
+		t.Fatalf("principal balance %d != balance after last paid row %d", resp.PrincipalBalanceCents, rows[len(rows)-1].BalanceCents)
+	}
+	if resp.PayoffAmountCents != resp.PrincipalBalanceCents+resp.AccruedInterestCents {
+		t.Fatalf("payoff %d != principal %d + accrued %d", resp.PayoffAmountCents, resp.PrincipalBalanceCents, resp.AccruedInterestCents)
+	}
+
+	// Every installment due by the payoff date is either paid or past due.
+	due := 0
+	for _, r := range schedule {
+		if r.Date <= req.PayoffDate {
+			due++
+		}
+	}
+	if resp.PaymentsMade+resp.InstallmentsPastDue != due {
+		t.Fatalf("paid %d + past due %d != %d due", resp.PaymentsMade, resp.InstallmentsPastDue, due)
+	}
+	if resp.GoodThroughDate < req.PayoffDate || (resp.NextDueDate != "" && resp.GoodThroughDate >= resp.NextDueDate) {
+		t.Fatalf("good through %s must run from payoff date %s to before next due %s", resp.GoodThroughDate, req.PayoffDate, resp.NextDueDate)
+	}
+
+	// Float cross-check: simple interest over the accrued days.
+	basis := 360.0
+	switch req.DayCount {
+	case calc.DayCountActual365:
+		basis = 365
+	case calc.DayCountActualActual:
+		payoff, _ := time.Parse("2006-01-02", req.PayoffDate)
+		basis = 365
+		if y := payoff.Year(); y%4 == 0 && (y%100 != 0 || y%400 == 0) {
+			basis = 366
+		}
+	}
+	perDay := float64(resp.PrincipalBalanceCents) * float64(req.AnnualRateBps) / 10000 / basis
+	if math.Abs(perDay-float64(resp.PerDiemCents)) > 0.5+1e-9 {
+		t.Fatalf("per diem %d, float cross-check %.4f", resp.PerDiemCents, perDay)
+	}
+	if req.DayCount != calc.DayCountActualActual {
+		if want := perDay * float64(resp.AccruedDays); math.Abs(want-float64(resp.AccruedInterestCents)) > 0.5+1e-6 {
+			t.Fatalf("accrued %d, float cross-check %.4f", resp.AccruedInterestCents, want)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package tests
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nicholaskarlson/proof-first-finance-calc/internal/calc"
+)
+
+func TestPitiV1_Goldens(t *testing.T) {
+	runCalendarGoldens(t, "piti", calc.PitiV1WithCalendar, calc.RenderPitiResponseJSON, calc.RenderPitiScheduleCSV, assertPitiInvariants)
+}
+
+func assertPitiInvariants(t *testing.T, req calc.PitiRequestV1, cal *calc.HolidayCalendar, resp calc.PitiResponseV1, rows []calc.PitiRow) {
+	t.Helper()
+	// The P&I side is Amortize v1, untouched.
+	amort, schedule, err := calc.AmortizeV1WithCalendar(req.AmortizeRequestV1, cal)
+	if err != nil {
+		t.Fatalf("AmortizeV1: %v", err)
+	}
+	if !reflect.DeepEqual(resp.Amortization, amort) {
+		t.Fatalf("amortization differs from AmortizeV1")
+	}
+	if len(rows) != len(schedule) {
+		t.Fatalf("rows %d, schedule %d", len(rows), len(schedule))
+	}
+
+	// Each monthly component is the annual amount over 12, rounded half-up.
+	if abs64(12*resp.MonthlyPropertyTaxCents-req.AnnualPropertyTaxCents) > 6 || abs64(12*resp.MonthlyInsuranceCents-req.AnnualInsuranceCents) > 6 {
+		t.Fatalf("monthly escrow %d/%d is not annual %d/%d over 12", resp.MonthlyPropertyTaxCents, resp.MonthlyInsuranceCents, req.AnnualPropertyTaxCents, req.AnnualInsuranceCents)
+	}
+	if premium := req.PrincipalCents * req.PMIAnnualRateBps; abs64(12*10000*resp.MonthlyPMICents-premium) > 6*10000 {
+		t.Fatalf("monthly pmi %d is not %d bps of %d over 12", resp.MonthlyPMICents, req.PMIAnnualRateBps, req.PrincipalCents)
+	}
+
+	cancelLTV := req.PMICancelLTVBps
+	if cancelLTV == 0 {
+		cancelLTV = calc.DefaultPMICancelLTVBps
+	}
+	var tax, ins, pmi, hoa, total int64
+	pmiPayments, cancelPeriod := 0, 0
+	// Capitalized odd-period interest is part of the first balance.
+	balance := req.PrincipalCents
+	if odd := resp.Amortization.OddPeriod; odd != nil && odd.Treatment == calc.OddInterestCapitalize {
+		balance += odd.OddInterestCents
+	}
+	for i, r := range rows {
+		s := schedule[i]
+		if r.Period != s.Period || r.Date != s.Date || r.PaymentCents != s.PaymentCents || r.PrincipalCents != s.PrincipalCents || r.InterestCents != s.InterestCents || r.BalanceCents != s.BalanceCents {
+			t.Fatalf("row %d: P&I columns differ from AmortizeV1", r.Period)
+		}
+		if r.PropertyTaxCents != resp.MonthlyPropertyTaxCents || r.InsuranceCents != resp.MonthlyInsuranceCents || r.HOACents != req.MonthlyHOACents {
+			t.Fatalf("row %d: escrow or HOA differs from the monthly amounts", r.Period)
+		}
+		// PMI is charged exactly while the balance before the payment is
+		// above the cancel LTV of the original value.
+		charged := resp.MonthlyPMICents > 0 && cancelPeriod == 0 && balance*10000 > req.PropertyValueCents*cancelLTV
+		switch {
+		case charged && r.PMICents != resp.MonthlyPMICents:
+			t.Fatalf("row %d: pmi %d, want %d", r.Period, r.PMICents, resp.MonthlyPMICents)
+		case !charged && r.PMICents != 0:
+			t.Fatalf("row %d: pmi %d after cancellation", r.Period, r.PMICents)
+		}
+		if charged {
+			pmiPayments++
+		} else if pmiPayments > 0 && cancelPeriod == 0 {
+			cancelPeriod = r.Period
+		}
+		if want := r.PaymentCents + r.PropertyTaxCents + r.InsuranceCents + r.PMICents + r.HOACents; r.TotalPaymentCents != want {
+			t.Fatalf("row %d: total %d, want %d", r.Period, r.TotalPaymentCents, want)
+		}
+		tax += r.PropertyTaxCents
+		ins += r.InsuranceCents
+		pmi += r.PMICents
+		hoa += r.HOACents
+		total += r.TotalPaymentCents
+		balance = r.BalanceCents
+	}
+
+	if resp.PMIPayments != pmiPayments || resp.PMICancelPeriod != cancelPeriod {
+		t.Fatalf("pmi payments %d cancel %d, want %d cancel %d", resp.PMIPayments, resp.PMICancelPeriod, pmiPayments, cancelPeriod)
+	}
+	if resp.TotalPropertyTaxCents != tax || resp.TotalInsuranceCents != ins || resp.TotalPMICents != pmi || resp.TotalHOACents != hoa || resp.TotalPaymentsCents != total {
+		t.Fatalf("totals do not match the rows")
+	}
+	if resp.TotalEscrowCents != tax+ins || resp.MonthlyEscrowCents != resp.MonthlyPropertyTaxCents+resp.MonthlyInsuranceCents {
+		t.Fatalf("escrow is not property tax + insurance")
+	}
+	if want := amort.TotalPaidCents + tax + ins + pmi + hoa; total != want {
+		t.Fatalf("total payments %d, want P&I %d + escrow, pmi and hoa = %d", total, amort.TotalPaidCents, want)
+	}
+	if len(rows) > 0 && resp.InitialTotalPaymentCents != rows[0].TotalPaymentCents {
+		t.Fatalf("initial total %d, first row %d", resp.InitialTotalPaymentCents, rows[0].TotalPaymentCents)
+	}
+}
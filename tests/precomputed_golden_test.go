@@ -0,0 +1,80 @@
+package tests
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/nicholaskarlson/proof-first-finance-calc/internal/calc"
+)
+
+func TestPrecomputedV1_Goldens(t *testing.T) {
+	runGoldens(t, "precomputed", calc.PrecomputedV1, calc.RenderPrecomputedResponseJSON, calc.RenderPrecomputedScheduleCSV, assertPrecomputedRebate)
+}
+
+// assertPrecomputedRebate proves the rebate against independent sources:
+// the actuarial payoff is the AmortizeV1 balance, the Rule of 78s rebate
+// is the digits fraction of the finance charge, and the rows tie out.
+func assertPrecomputedRebate(t *testing.T, req calc.PrecomputedRequestV1, resp calc.PrecomputedResponseV1, rows []calc.PrecomputedRow) {
+	t.Helper()
+	amort, schedule, err := calc.AmortizeV1(calc.AmortizeRequestV1{
+		PrincipalCents: req.PrincipalCents,
+		AnnualRateBps:  req.AnnualRateBps,
+		TermMonths:     req.TermMonths,
+		StartDate:      req.StartDate,
+	})
+	if err != nil {
+		t.Fatalf("AmortizeV1: %v", err)
+	}
+	if resp.FinanceChargeCents != amort.TotalInterestCents || resp.TotalOfPaymentsCents != req.PrincipalCents+resp.FinanceChargeCents {
+		t.Fatalf("finance charge %d does not match the contract schedule", resp.FinanceChargeCents)
+	}
+	if len(rows) != len(schedule) {
+		t.Fatalf("%d rows, want %d", len(rows), len(schedule))
+	}
+
+	n := int64(len(rows))
+	k := resp.PayoffPeriod
+	balance := req.PrincipalCents
+	if k > 0 {
+		balance = schedule[k-1].BalanceCents
+	}
+	if resp.Method == calc.RebateActuarial && resp.PayoffCents != balance {
+		t.Fatalf("actuarial payoff %d, want balance %d", resp.PayoffCents, balance)
+	}
+	if got := resp.RemainingPaymentsCents - resp.ActuarialRebateCents; got != balance {
+		t.Fatalf("remaining payments less actuarial rebate = %d, want balance %d", got, balance)
+	}
+	left := n - int64(k)
+	want := new(big.Rat).SetFrac64(resp.FinanceChargeCents*left*(left+1), n*(n+1))
+	if diff := new(big.Rat).Sub(want, big.NewRat(resp.RuleOf78sRebateCents, 1)); diff.Abs(diff).Cmp(big.NewRat(1, 2)) > 0 {
+		t.Fatalf("rule of 78s rebate %d, want %s", resp.RuleOf78sRebateCents, want.FloatString(2))
+	}
+	if resp.RuleOf78sPenaltyCents < 0 {
+		t.Fatalf("rule of 78s rebate %d exceeds the actuarial rebate %d", resp.RuleOf78sRebateCents, resp.ActuarialRebateCents)
+	}
+
+	if resp.EarnedInterestCents+resp.UnearnedInterestCents != resp.FinanceChargeCents || resp.RebateCents != resp.UnearnedInterestCents {
+		t.Fatalf("earned and unearned interest do not split the finance charge")
+	}
+	if resp.PaymentsMadeCents+resp.RemainingPaymentsCents != resp.TotalOfPaymentsCents || resp.PayoffCents != resp.RemainingPaymentsCents-resp.RebateCents {
+		t.Fatalf("payoff does not tie out")
+	}
+
+	unearned, remaining := resp.FinanceChargeCents, resp.TotalOfPaymentsCents
+	for i, r := range rows {
+		remaining -= r.PaymentCents
+		if r.PaymentCents != schedule[i].PaymentCents || r.EarnedInterestCents < 0 || r.UnearnedInterestCents != unearned-r.EarnedInterestCents {
+			t.Fatalf("row %d does not tie out", r.Period)
+		}
+		if r.RemainingPaymentsCents != remaining || r.PayoffCents != remaining-r.UnearnedInterestCents {
+			t.Fatalf("row %d: payoff does not tie out", r.Period)
+		}
+		if i+1 == k && (r.UnearnedInterestCents != resp.RebateCents || r.PayoffCents != resp.PayoffCents) {
+			t.Fatalf("row %d does not match the payoff quote", r.Period)
+		}
+		unearned = r.UnearnedInterestCents
+	}
+	if unearned != 0 || remaining != 0 {
+		t.Fatalf("schedule ends with %d unearned and %d remaining", unearned, remaining)
+	}
+}
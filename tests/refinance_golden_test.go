@@ -0,0 +1,102 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/nicholaskarlson/proof-first-finance-calc/internal/calc"
+)
+
+func TestRefinanceV1_Goldens(t *testing.T) {
+	runCalendarGoldens(t, "refinance", calc.RefinanceV1WithCalendar, calc.RenderRefinanceResponseJSON, calc.RenderRefinanceScheduleCSV, assertRefinanceInvariants)
+}
+
+func assertRefinanceInvariants(t *testing.T, req calc.RefinanceRequestV1, cal *calc.HolidayCalendar, resp calc.RefinanceResponseV1, rows []calc.RefinanceRow) {
+	t.Helper()
+	// Each side of the comparison is an Amortize v1 schedule, row for row.
+	_, existing, err := calc.AmortizeV1WithCalendar(req.Existing, cal)
+	if err != nil {
+		t.Fatalf("AmortizeV1 existing: %v", err)
+	}
+	remaining := existing[req.PaymentsMade:]
+	_, refi, err := calc.AmortizeV1WithCalendar(calc.AmortizeRequestV1{
+		PrincipalCents: resp.NewPrincipalCents,
+		AnnualRateBps:  req.NewAnnualRateBps,
+		TermMonths:     req.NewTermMonths,
+		StartDate:      resp.NewStartDate,
+		DateRoll:       req.Existing.DateRoll,
+		DayCount:       req.Existing.DayCount,
+	}, cal)
+	if err != nil {
+		t.Fatalf("AmortizeV1 new: %v", err)
+	}
+	if want := max(len(remaining), len(refi)); len(rows) != want {
+		t.Fatalf("rows %d, want %d", len(rows), want)
+	}
+
+	// The refinanced balance opens the first remaining row.
+	if open := remaining[0].BalanceCents + remaining[0].PrincipalCents; resp.ExistingRemainingBalanceCents != open {
+		t.Fatalf("remaining balance %d, want %d", resp.ExistingRemainingBalanceCents, open)
+	}
+	wantPrincipal := resp.ExistingRemainingBalanceCents
+	if req.RollInClosingCosts {
+		wantPrincipal += req.ClosingCostsCents
+	}
+	if resp.NewPrincipalCents != wantPrincipal {
+		t.Fatalf("new principal %d, want %d", resp.NewPrincipalCents, wantPrincipal)
+	}
+
+	var existingPaid, existingInterest, newPaid, newInterest, cumulative int64
+	breakEven := 0
+	for i, r := range rows {
+		if r.Month != i+1 {
+			t.Fatalf("row %d: month %d", i, r.Month)
+		}
+		if i < len(remaining) {
+			e := remaining[i]
+			if r.ExistingDate != e.Date || r.ExistingPaymentCents != e.PaymentCents || r.ExistingInterestCents != e.InterestCents || r.ExistingBalanceCents != e.BalanceCents {
+				t.Fatalf("month %d: existing side does not match schedule row %d", r.Month, req.PaymentsMade+i+1)
+			}
+		} else if r.ExistingDate != "" || r.ExistingPaymentCents != 0 {
+			t.Fatalf("month %d: existing side after payoff", r.Month)
+		}
+		if i < len(refi) {
+			n := refi[i]
+			if r.NewDate != n.Date || r.NewPaymentCents != n.PaymentCents || r.NewInterestCents != n.InterestCents || r.NewBalanceCents != n.BalanceCents {
+				t.Fatalf("month %d: new side does not match schedule row %d", r.Month, i+1)
+			}
+		} else if r.NewDate != "" || r.NewPaymentCents != 0 {
+			t.Fatalf("month %d: new side after payoff", r.Month)
+		}
+		existingPaid += r.ExistingPaymentCents
+		existingInterest += r.ExistingInterestCents
+		newPaid += r.NewPaymentCents
+		newInterest += r.NewInterestCents
+		cumulative += r.ExistingPaymentCents - r.NewPaymentCents
+		if r.CumulativeSavingsCents != cumulative {
+			t.Fatalf("month %d: cumulative savings %d, want %d", r.Month, r.CumulativeSavingsCents, cumulative)
+		}
+		if breakEven == 0 && cumulative >= req.ClosingCostsCents {
+			breakEven = r.Month
+		}
+	}
+
+	if resp.ExistingRemainingPaymentsCents != existingPaid || resp.ExistingRemainingInterestCents != existingInterest {
+		t.Fatalf("existing totals %d/%d, rows sum to %d/%d", resp.ExistingRemainingPaymentsCents, resp.ExistingRemainingInterestCents, existingPaid, existingInterest)
+	}
+	if resp.NewTotalPaymentsCents != newPaid || resp.NewTotalInterestCents != newInterest {
+		t.Fatalf("new totals %d/%d, rows sum to %d/%d", resp.NewTotalPaymentsCents, resp.NewTotalInterestCents, newPaid, newInterest)
+	}
+	if resp.BreakEvenMonth != breakEven || resp.BreaksEven != (breakEven > 0) {
+		t.Fatalf("break even %v month %d, want month %d", resp.BreaksEven, resp.BreakEvenMonth, breakEven)
+	}
+	outOfPocket := req.ClosingCostsCents
+	if req.RollInClosingCosts {
+		outOfPocket = 0
+	}
+	if resp.NetSavingsCents != existingPaid-newPaid-outOfPocket {
+		t.Fatalf("net savings %d, want %d", resp.NetSavingsCents, existingPaid-newPaid-outOfPocket)
+	}
+	if resp.InterestDifferenceCents != existingInterest-newInterest {
+		t.Fatalf("interest difference %d, want %d", resp.InterestDifferenceCents, existingInterest-newInterest)
+	}
+}
@@ -0,0 +1,125 @@
+package tests
+
+import (
+	"math"
+	"testing"
+
+	"github.com/nicholaskarlson/proof-first-finance-calc/internal/calc"
+)
+
+var periodsPerYear = map[string]int64{
+	"weekly": 52, "biweekly": 26, "semi_monthly": 24, "monthly": 12,
+	"quarterly": 4, "semi_annual": 2, "annual": 1,
+}
+
+func TestFutureValueV1_Goldens(t *testing.T) {
+	runGoldens(t, "future_value", calc.FutureValueV1, calc.RenderFutureValueResponseJSON, calc.RenderSavingsScheduleCSV, func(t *testing.T, req calc.FutureValueRequestV1, resp calc.FutureValueResponseV1, rows []calc.SavingsRow) {
+		assertSavingsLedger(t, req.PresentValueCents, resp.AnnualRateBps, periodsPerYear[resp.CompoundingFrequency], false, rows)
+		if resp.FutureValueCents != resp.PresentValueCents+resp.TotalInterestCents {
+			t.Fatalf("future value %d != present value %d + interest %d", resp.FutureValueCents, resp.PresentValueCents, resp.TotalInterestCents)
+		}
+		i := float64(resp.AnnualRateBps) / 10000 / float64(periodsPerYear[resp.CompoundingFrequency])
+		assertNearClosedForm(t, resp.FutureValueCents, float64(req.PresentValueCents)*math.Pow(1+i, float64(resp.NumPeriods)), i, resp.NumPeriods)
+	})
+}
+
+func TestAnnuityV1_Goldens(t *testing.T) {
+	runGoldens(t, "annuity", calc.AnnuityV1, calc.RenderAnnuityResponseJSON, calc.RenderSavingsScheduleCSV, func(t *testing.T, req calc.AnnuityRequestV1, resp calc.AnnuityResponseV1, rows []calc.SavingsRow) {
+		due := resp.Timing == calc.TimingDue
+		assertSavingsLedger(t, req.InitialBalanceCents, resp.AnnualRateBps, periodsPerYear[resp.PaymentFrequency], due, rows)
+		if resp.FutureValueCents != resp.InitialBalanceCents+resp.TotalContributionsCents+resp.TotalInterestCents {
+			t.Fatalf("future value %d does not tie to initial + contributions + interest", resp.FutureValueCents)
+		}
+		i := float64(resp.AnnualRateBps) / 10000 / float64(periodsPerYear[resp.PaymentFrequency])
+		assertNearClosedForm(t, resp.FutureValueCents, annuityFV(float64(req.InitialBalanceCents), float64(req.ContributionCents), i, resp.NumPeriods, due), i, resp.NumPeriods)
+	})
+}
+
+func TestSinkingFundV1_Goldens(t *testing.T) {
+	runGoldens(t, "sinking_fund", calc.SinkingFundV1, calc.RenderSinkingFundResponseJSON, calc.RenderSavingsScheduleCSV, func(t *testing.T, req calc.SinkingFundRequestV1, resp calc.SinkingFundResponseV1, rows []calc.SavingsRow) {
+		due := resp.Timing == calc.TimingDue
+		assertSavingsLedger(t, req.InitialBalanceCents, resp.AnnualRateBps, periodsPerYear[resp.PaymentFrequency], due, rows)
+		if resp.ExcessCents < 0 || resp.ExcessCents != resp.FutureValueCents-resp.TargetCents {
+			t.Fatalf("excess %d must be future value %d - target %d >= 0", resp.ExcessCents, resp.FutureValueCents, resp.TargetCents)
+		}
+		// Minimal: one cent less must fall short of the target.
+		if resp.PaymentCents == 0 {
+			return
+		}
+		var short int64
+		if resp.PaymentCents > 1 {
+			a, _, err := calc.AnnuityV1(calc.AnnuityRequestV1{
+				ContributionCents:   resp.PaymentCents - 1,
+				InitialBalanceCents: req.InitialBalanceCents,
+				AnnualRateBps:       req.AnnualRateBps,
+				TermMonths:          req.TermMonths,
+				PaymentFrequency:    req.PaymentFrequency,
+				Timing:              req.Timing,
+			})
+			if err != nil {
+				t.Fatalf("AnnuityV1: %v", err)
+			}
+			short = a.FutureValueCents
+		} else if req.InitialBalanceCents > 0 {
+			f, _, err := calc.FutureValueV1(calc.FutureValueRequestV1{
+				PresentValueCents:    req.InitialBalanceCents,
+				AnnualRateBps:        req.AnnualRateBps,
+				TermMonths:           req.TermMonths,
+				CompoundingFrequency: req.PaymentFrequency,
+			})
+			if err != nil {
+				t.Fatalf("FutureValueV1: %v", err)
+			}
+			short = f.FutureValueCents
+		}
+		if short >= resp.TargetCents {
+			t.Fatalf("payment %d is not minimal: %d cents reaches %d >= target %d", resp.PaymentCents, resp.PaymentCents-1, short, resp.TargetCents)
+		}
+	})
+}
+
+// assertSavingsLedger replays the accumulation: each row's interest is the
+// half-up rounded periodic interest on the balance it accrues on, and each
+// balance is the previous one plus contribution and interest.
+func assertSavingsLedger(t *testing.T, initial, annualRateBps, perYear int64, due bool, rows []calc.SavingsRow) {
+	t.Helper()
+	bal := initial
+	for _, r := range rows {
+		base := bal
+		if due {
+			base += r.ContributionCents
+		}
+		num := base * annualRateBps
+		den := 10000 * perYear
+		if want := (2*num + den) / (2 * den); r.InterestCents != want {
+			t.Fatalf("row %d: interest %d, want %d on %d", r.Period, r.InterestCents, want, base)
+		}
+		bal += r.ContributionCents + r.InterestCents
+		if r.BalanceCents != bal {
+			t.Fatalf("row %d: balance %d, want %d", r.Period, r.BalanceCents, bal)
+		}
+	}
+}
+
+// assertNearClosedForm allows the per-period cent rounding to drift the
+// ledger from the exact closed form by at most half a cent per period,
+// compounded over the remaining periods.
+func assertNearClosedForm(t *testing.T, got int64, exact, i float64, n int) {
+	t.Helper()
+	if math.Abs(float64(got)-exact) > 0.5*float64(n)*math.Pow(1+i, float64(n))+1e-6 {
+		t.Fatalf("future value %d, closed form %.4f", got, exact)
+	}
+}
+
+func annuityFV(initial, pmt, i float64, n int, due bool) float64 {
+	g := math.Pow(1+i, float64(n))
+	fv := initial * g
+	if i == 0 {
+		return fv + pmt*float64(n)
+	}
+	a := pmt * (g - 1) / i
+	if due {
+		a *= 1 + i
+	}
+	return fv + a
+}
@@ -0,0 +1,82 @@
+package tests
+
+import (
+	"math"
+	"testing"
+
+	"github.com/nicholaskarlson/proof-first-finance-calc/internal/calc"
+)
+
+func TestSimpleInterestV1_Goldens(t *testing.T) {
+	runGoldens(t, "simple_interest", calc.SimpleInterestV1, calc.RenderSimpleInterestResponseJSON, calc.RenderSimpleInterestLedgerCSV, assertSimpleInterestLedger)
+}
+
+// assertSimpleInterestLedger checks that every payment is applied interest
+// first, balances carry from row to row, and the accruals agree with a
+// float computation of simple interest.
+func assertSimpleInterestLedger(t *testing.T, req calc.SimpleInterestRequestV1, resp calc.SimpleInterestResponseV1, rows []calc.SimpleInterestRow) {
+	t.Helper()
+	if len(rows) != len(req.Payments) {
+		t.Fatalf("rows %d, payments %d", len(rows), len(req.Payments))
+	}
+	balance, unpaid := req.PrincipalCents, int64(0)
+	var interestPaid, principalPaid int64
+	for i, r := range rows {
+		p := req.Payments[i]
+		if r.Payment != i+1 || r.Date != p.Date || r.PaymentCents != p.AmountCents {
+			t.Fatalf("row %d does not echo payment %d", r.Payment, i)
+		}
+		due := unpaid + r.InterestAccruedCents
+		if r.InterestPaidCents != min(r.PaymentCents, due) {
+			t.Fatalf("row %d: interest paid %d, want min(payment %d, due %d)", r.Payment, r.InterestPaidCents, r.PaymentCents, due)
+		}
+		if r.InterestPaidCents+r.PrincipalCents != r.PaymentCents {
+			t.Fatalf("row %d: interest %d + principal %d != payment %d", r.Payment, r.InterestPaidCents, r.PrincipalCents, r.PaymentCents)
+		}
+		if r.UnpaidInterestCents != due-r.InterestPaidCents || r.BalanceCents != balance-r.PrincipalCents {
+			t.Fatalf("row %d: balances do not carry forward", r.Payment)
+		}
+		if r.BalanceCents < 0 || r.UnpaidInterestCents < 0 {
+			t.Fatalf("row %d: negative balance", r.Payment)
+		}
+		assertSimpleAccrual(t, resp.DayCount, balance, req.AnnualRateBps, r.Days, r.InterestAccruedCents)
+		balance, unpaid = r.BalanceCents, r.UnpaidInterestCents
+		interestPaid += r.InterestPaidCents
+		principalPaid += r.PrincipalCents
+	}
+
+	if resp.TotalInterestPaidCents != interestPaid || resp.TotalPrincipalPaidCents != principalPaid || resp.TotalPaidCents != interestPaid+principalPaid {
+		t.Fatalf("totals do not match the ledger")
+	}
+	if resp.PrincipalBalanceCents != balance || resp.UnpaidInterestCents != unpaid {
+		t.Fatalf("closing balances %d/%d, ledger ends at %d/%d", resp.PrincipalBalanceCents, resp.UnpaidInterestCents, balance, unpaid)
+	}
+	assertSimpleAccrual(t, resp.DayCount, balance, req.AnnualRateBps, resp.AccruedDays, resp.AccruedInterestCents)
+	if resp.PayoffAmountCents != balance+unpaid+resp.AccruedInterestCents {
+		t.Fatalf("payoff %d != principal %d + unpaid %d + accrued %d", resp.PayoffAmountCents, balance, unpaid, resp.AccruedInterestCents)
+	}
+	if resp.PaidOff != (resp.PayoffAmountCents == 0) {
+		t.Fatalf("paid_off %v with payoff %d", resp.PaidOff, resp.PayoffAmountCents)
+	}
+}
+
+// assertSimpleAccrual cross-checks balance * rate * days / basis in floats.
+// actual/actual spans years with different bases, so it is checked against
+// both bounds.
+func assertSimpleAccrual(t *testing.T, dayCount string, balance, rateBps, days, got int64) {
+	t.Helper()
+	bases := map[string][]float64{
+		calc.DayCount30360:        {360},
+		calc.DayCountActual360:    {360},
+		calc.DayCountActual365:    {365},
+		calc.DayCountActualActual: {365, 366},
+	}[dayCount]
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, b := range bases {
+		v := float64(balance) * float64(rateBps) / 10000 * float64(days) / b
+		lo, hi = math.Min(lo, v), math.Max(hi, v)
+	}
+	if float64(got) < lo-0.5-1e-6 || float64(got) > hi+0.5+1e-6 {
+		t.Fatalf("accrued %d over %d days, float cross-check %.4f..%.4f", got, days, lo, hi)
+	}
+}
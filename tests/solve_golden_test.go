@@ -0,0 +1,109 @@
+package tests
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nicholaskarlson/proof-first-finance-calc/internal/calc"
+)
+
+func TestSolveTermV1_Goldens(t *testing.T) {
+	runGoldens(t, "solve_term", calc.SolveTermV1, calc.RenderSolveResponseJSON, calc.RenderScheduleCSV, func(t *testing.T, _ calc.SolveTermRequestV1, resp calc.SolveResponseV1, rows []calc.ScheduleRow) {
+		assertSolvedLoanReplays(t, resp, rows)
+		// Minimal: one month shorter must overshoot the target payment.
+		a := resp.Amortization
+		if a.TermMonths > 1 {
+			if p := solvedPayment(t, a, func(r *calc.AmortizeRequestV1) { r.TermMonths-- }); p <= resp.TargetPaymentCents {
+				t.Fatalf("term %d is not minimal: term %d pays %d <= target %d", a.TermMonths, a.TermMonths-1, p, resp.TargetPaymentCents)
+			}
+		}
+		if resp.PaymentCents > resp.TargetPaymentCents {
+			t.Fatalf("payment %d exceeds target %d", resp.PaymentCents, resp.TargetPaymentCents)
+		}
+	})
+}
+
+func TestSolveRateV1_Goldens(t *testing.T) {
+	runGoldens(t, "solve_rate", calc.SolveRateV1, calc.RenderSolveResponseJSON, calc.RenderScheduleCSV, func(t *testing.T, _ calc.SolveRateRequestV1, resp calc.SolveResponseV1, rows []calc.ScheduleRow) {
+		assertSolvedLoanReplays(t, resp, rows)
+		// Closest payment; ties go to the lower rate.
+		a := resp.Amortization
+		diff := abs64(resp.PaymentDiffCents)
+		if a.AnnualRateBps > 0 {
+			p := solvedPayment(t, a, func(r *calc.AmortizeRequestV1) { r.AnnualRateBps-- })
+			if abs64(p-resp.TargetPaymentCents) <= diff {
+				t.Fatalf("rate %d bps loses the tie-break to %d bps (payment %d)", a.AnnualRateBps, a.AnnualRateBps-1, p)
+			}
+		}
+		if a.AnnualRateBps < calc.MaxAnnualRateBps {
+			p := solvedPayment(t, a, func(r *calc.AmortizeRequestV1) { r.AnnualRateBps++ })
+			if abs64(p-resp.TargetPaymentCents) < diff {
+				t.Fatalf("rate %d bps is closer than %d bps (payment %d)", a.AnnualRateBps+1, a.AnnualRateBps, p)
+			}
+		}
+	})
+}
+
+func TestSolvePrincipalV1_Goldens(t *testing.T) {
+	runGoldens(t, "solve_principal", calc.SolvePrincipalV1, calc.RenderSolveResponseJSON, calc.RenderScheduleCSV, func(t *testing.T, _ calc.SolvePrincipalRequestV1, resp calc.SolveResponseV1, rows []calc.ScheduleRow) {
+		assertSolvedLoanReplays(t, resp, rows)
+		// Maximal: one more cent must overshoot the target payment.
+		a := resp.Amortization
+		if resp.PaymentCents > resp.TargetPaymentCents {
+			t.Fatalf("payment %d exceeds target %d", resp.PaymentCents, resp.TargetPaymentCents)
+		}
+		if a.PrincipalCents < calc.MaxPrincipalCents {
+			if p := solvedPayment(t, a, func(r *calc.AmortizeRequestV1) { r.PrincipalCents++ }); p <= resp.TargetPaymentCents {
+				t.Fatalf("principal %d is not maximal: %d pays %d <= target %d", a.PrincipalCents, a.PrincipalCents+1, p, resp.TargetPaymentCents)
+			}
+		}
+	})
+}
+
+// assertSolvedLoanReplays checks that the solved loan replays exactly
+// through AmortizeV1 and that the payment diff ties out.
+func assertSolvedLoanReplays(t *testing.T, resp calc.SolveResponseV1, rows []calc.ScheduleRow) {
+	t.Helper()
+	if resp.PaymentDiffCents != resp.PaymentCents-resp.TargetPaymentCents {
+		t.Fatalf("payment diff %d != payment %d - target %d", resp.PaymentDiffCents, resp.PaymentCents, resp.TargetPaymentCents)
+	}
+	a := resp.Amortization
+	replayReq := calc.AmortizeRequestV1{
+		PrincipalCents: a.PrincipalCents,
+		AnnualRateBps:  a.AnnualRateBps,
+		TermMonths:     a.TermMonths,
+		StartDate:      a.StartDate,
+	}
+	replay, replayRows, err := calc.AmortizeV1(replayReq)
+	if err != nil {
+		t.Fatalf("replay AmortizeV1: %v", err)
+	}
+	if !reflect.DeepEqual(replay, a) || !reflect.DeepEqual(replayRows, rows) {
+		t.Fatalf("solved loan does not replay through AmortizeV1")
+	}
+	assertScheduleInvariants(t, replayReq, a, rows)
+}
+
+// solvedPayment returns the level payment of the solved loan after tweak.
+func solvedPayment(t *testing.T, a calc.AmortizeResponseV1, tweak func(*calc.AmortizeRequestV1)) int64 {
+	t.Helper()
+	req := calc.AmortizeRequestV1{
+		PrincipalCents: a.PrincipalCents,
+		AnnualRateBps:  a.AnnualRateBps,
+		TermMonths:     a.TermMonths,
+		StartDate:      a.StartDate,
+	}
+	tweak(&req)
+	resp, _, err := calc.AmortizeV1(req)
+	if err != nil {
+		t.Fatalf("AmortizeV1: %v", err)
+	}
+	return resp.PaymentCents
+}
+
+func abs64(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}